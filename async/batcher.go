@@ -0,0 +1,147 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type BatchOptions struct {
+	// Window is how long keys are collected before the batch function is called, 1ms by default
+	Window time.Duration
+	// MaxBatch calls the batch function as soon as that many keys are collected, batches are unbounded when it is not positive
+	MaxBatch int
+	Clock    Clock
+	// TaskOptions are applied to the tasks running the batch function
+	TaskOptions []TaskOption
+}
+
+// BatchErrors fails the keys it holds, the other keys of the batch resolve with the values returned along with it
+type BatchErrors[K comparable] map[K]error
+
+func (e BatchErrors[K]) Error() string {
+	return fmt.Sprintf("batch failed for %d keys", len(e))
+}
+
+// Batcher collects the keys of concurrent loads and resolves them with a single call of its batch function
+type Batcher[K comparable, V any] struct {
+	fn   func(ctx context.Context, keys []K) (map[K]V, error)
+	opts BatchOptions
+
+	mtx     sync.Mutex
+	pending *batch[K, V]
+}
+
+type batch[K comparable, V any] struct {
+	ctx        context.Context
+	keys       []K
+	index      map[K]struct{}
+	dispatched chan struct{}
+	task       Task[map[K]V]
+}
+
+func NewBatcher[K comparable, V any](fn func(ctx context.Context, keys []K) (map[K]V, error), opts BatchOptions) *Batcher[K, V] {
+	if opts.Window <= 0 {
+		opts.Window = time.Millisecond
+	}
+	if opts.Clock == nil {
+		opts.Clock = globalClock()
+	}
+
+	return &Batcher[K, V]{
+		fn:   fn,
+		opts: opts,
+	}
+}
+
+// Load adds key to the pending batch, the batch function gets the context of the first load of the batch without its cancellation
+func (b *Batcher[K, V]) Load(ctx context.Context, key K, opts ...TaskOption) Task[V] {
+	if ctx == nil {
+		ctx = context.TODO()
+	}
+	if b.fn == nil {
+		return NewErrTask[V](ctx, ErrNilFuncEncountered)
+	}
+
+	bt := b.add(ctx, key)
+
+	return NewTask(ctx, func() (V, error) {
+		<-bt.dispatched
+		results, err := bt.task.Await()
+
+		var batchErrs BatchErrors[K]
+		switch {
+		case errors.As(err, &batchErrs):
+			if keyErr, ok := batchErrs[key]; ok {
+				var zero V
+				return zero, keyErr
+			}
+		case err != nil:
+			var zero V
+			return zero, err
+		}
+
+		result, ok := results[key]
+		if !ok {
+			return result, ErrBatchKeyMissing
+		}
+
+		return result, nil
+	}, opts...)
+}
+
+func (b *Batcher[K, V]) add(ctx context.Context, key K) *batch[K, V] {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	bt := b.pending
+	if bt == nil {
+		bt = &batch[K, V]{
+			ctx:        context.WithoutCancel(ctx),
+			index:      make(map[K]struct{}),
+			dispatched: make(chan struct{}),
+		}
+		b.pending = bt
+		go b.await(bt)
+	}
+
+	if _, ok := bt.index[key]; !ok {
+		bt.index[key] = struct{}{}
+		bt.keys = append(bt.keys, key)
+	}
+	if b.opts.MaxBatch > 0 && len(bt.keys) >= b.opts.MaxBatch {
+		b.dispatch(bt)
+	}
+
+	return bt
+}
+
+// await dispatches bt once its window passes
+func (b *Batcher[K, V]) await(bt *batch[K, V]) {
+	timer := b.opts.Clock.NewTimer(b.opts.Window)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C():
+	case <-bt.dispatched:
+		return
+	}
+
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if b.pending == bt {
+		b.dispatch(bt)
+	}
+}
+
+// dispatch must be called with b.mtx locked
+func (b *Batcher[K, V]) dispatch(bt *batch[K, V]) {
+	b.pending = nil
+	bt.task = NewTask(bt.ctx, func() (map[K]V, error) {
+		return b.fn(bt.ctx, bt.keys)
+	}, b.opts.TaskOptions...)
+	close(bt.dispatched)
+}
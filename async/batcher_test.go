@@ -0,0 +1,99 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatcher_Load(t *testing.T) {
+	err := errors.New("i am error")
+
+	recording := func(batches *[][]int, mtx *sync.Mutex, err error) func(ctx context.Context, keys []int) (map[int]string, error) {
+		return func(ctx context.Context, keys []int) (map[int]string, error) {
+			mtx.Lock()
+			*batches = append(*batches, keys)
+			mtx.Unlock()
+
+			results := make(map[int]string, len(keys))
+			for _, key := range keys {
+				if key >= 0 {
+					results[key] = fmt.Sprint(key)
+				}
+			}
+			return results, err
+		}
+	}
+
+	t.Run("collects keys within the window", func(t *testing.T) {
+		var (
+			batches [][]int
+			mtx     sync.Mutex
+		)
+		b := NewBatcher(recording(&batches, &mtx, nil), BatchOptions{Window: 20 * time.Millisecond})
+
+		tasks := []Task[string]{b.Load(nil, 1), b.Load(nil, 2), b.Load(nil, 1), b.Load(nil, -1)}
+		for i, expected := range []string{"1", "2", "1"} {
+			result, err := tasks[i].Await()
+			require.NoError(t, err)
+			require.Equal(t, expected, result)
+		}
+		_, loadErr := tasks[3].Await()
+		require.ErrorIs(t, loadErr, ErrBatchKeyMissing)
+		require.Equal(t, [][]int{{1, 2, -1}}, batches)
+	})
+
+	t.Run("dispatches full batches", func(t *testing.T) {
+		var (
+			batches [][]int
+			mtx     sync.Mutex
+		)
+		b := NewBatcher(recording(&batches, &mtx, nil), BatchOptions{Window: time.Hour, MaxBatch: 2})
+
+		tasks := []Task[string]{b.Load(nil, 1), b.Load(nil, 2), b.Load(nil, 3), b.Load(nil, 4)}
+		for _, task := range tasks {
+			_, err := task.Await()
+			require.NoError(t, err)
+		}
+		require.ElementsMatch(t, [][]int{{1, 2}, {3, 4}}, batches)
+	})
+
+	t.Run("batch and per-key errors", func(t *testing.T) {
+		var (
+			batches [][]int
+			mtx     sync.Mutex
+		)
+		b := NewBatcher(recording(&batches, &mtx, err), BatchOptions{})
+		_, loadErr := b.Load(nil, 1).Await()
+		require.ErrorIs(t, loadErr, err)
+
+		keyErr := errors.New("not found")
+		b = NewBatcher(recording(&batches, &mtx, BatchErrors[int]{2: keyErr}), BatchOptions{Window: 20 * time.Millisecond})
+		first, second := b.Load(nil, 1), b.Load(nil, 2)
+
+		result, loadErr := first.Await()
+		require.NoError(t, loadErr)
+		require.Equal(t, "1", result)
+		_, loadErr = second.Await()
+		require.ErrorIs(t, loadErr, keyErr)
+	})
+
+	t.Run("cancelled load", func(t *testing.T) {
+		var (
+			batches [][]int
+			mtx     sync.Mutex
+		)
+		b := NewBatcher(recording(&batches, &mtx, nil), BatchOptions{Window: time.Hour})
+		ctx, cancel := context.WithCancel(context.TODO())
+		task := b.Load(ctx, 1)
+		cancel()
+
+		_, loadErr := task.Await()
+		require.ErrorIs(t, loadErr, ErrTaskContextCancelled)
+	})
+}
@@ -0,0 +1,121 @@
+package async
+
+import (
+	"context"
+	"sync"
+)
+
+// Bulkhead limits the number of concurrently running tasks per key
+type Bulkhead struct {
+	limit int
+	queue int
+
+	mtx  sync.Mutex
+	keys map[string]*bulkheadKey
+}
+
+type bulkheadKey struct {
+	slots   chan struct{}
+	waiting int
+}
+
+// NewBulkhead allows limit tasks per key to run at once and queue more of them to wait for a slot,
+// negative queue lets any number of tasks wait
+func NewBulkhead(limit, queue int) *Bulkhead {
+	if limit <= 0 {
+		limit = 1
+	}
+
+	return &Bulkhead{
+		limit: limit,
+		queue: queue,
+		keys:  make(map[string]*bulkheadKey),
+	}
+}
+
+// WithBulkhead holds back the task function until a slot of key in b is free
+func WithBulkhead(b *Bulkhead, key string) TaskOption {
+	return func(cfg *taskConfig) {
+		if b == nil {
+			return
+		}
+		cfg.gates = append(cfg.gates, func(ctx context.Context) (func(), error) {
+			return b.Acquire(ctx, key)
+		})
+	}
+}
+
+// Acquire waits for a slot of key, it returns ErrBulkheadFull when the queue of key is full
+// and the cause of ctx when it is done before a slot is free
+func (b *Bulkhead) Acquire(ctx context.Context, key string) (release func(), err error) {
+	if ctx == nil {
+		ctx = context.TODO()
+	}
+
+	b.mtx.Lock()
+	k, ok := b.keys[key]
+	if !ok {
+		k = &bulkheadKey{slots: make(chan struct{}, b.limit)}
+		b.keys[key] = k
+	}
+
+	select {
+	case k.slots <- struct{}{}:
+		b.mtx.Unlock()
+		return b.releaser(key, k), nil
+	default:
+	}
+
+	if b.queue >= 0 && k.waiting >= b.queue {
+		b.mtx.Unlock()
+		return nil, ErrBulkheadFull
+	}
+	k.waiting++
+	b.mtx.Unlock()
+
+	select {
+	case k.slots <- struct{}{}:
+		b.mtx.Lock()
+		k.waiting--
+		b.mtx.Unlock()
+		return b.releaser(key, k), nil
+	case <-ctx.Done():
+		b.mtx.Lock()
+		k.waiting--
+		b.forget(key, k)
+		b.mtx.Unlock()
+		return nil, context.Cause(ctx)
+	}
+}
+
+// InFlight returns the number of slots of key taken
+func (b *Bulkhead) InFlight(key string) int {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if k, ok := b.keys[key]; ok {
+		return len(k.slots)
+	}
+
+	return 0
+}
+
+func (b *Bulkhead) releaser(key string, k *bulkheadKey) func() {
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			<-k.slots
+			b.mtx.Lock()
+			b.forget(key, k)
+			b.mtx.Unlock()
+		})
+	}
+}
+
+// forget drops idle keys, it must be called with b.mtx locked
+func (b *Bulkhead) forget(key string, k *bulkheadKey) {
+	if len(k.slots) == 0 && k.waiting == 0 {
+		delete(b.keys, key)
+	}
+}
@@ -0,0 +1,118 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkhead(t *testing.T) {
+	t.Run("limits tasks per key", func(t *testing.T) {
+		b := NewBulkhead(1, -1)
+		started, release := make(chan string, 3), make(chan struct{})
+		blocking := func(name string) func() (string, error) {
+			return func() (string, error) {
+				started <- name
+				<-release
+				return name, nil
+			}
+		}
+
+		first := NewTask(nil, blocking("first"), WithBulkhead(b, "billing"))
+		require.Equal(t, "first", <-started)
+		second := NewTask(nil, blocking("second"), WithBulkhead(b, "billing"))
+		other := NewTask(nil, blocking("other"), WithBulkhead(b, "search"))
+		require.Equal(t, "other", <-started)
+
+		select {
+		case name := <-started:
+			t.Fatalf("%s started while the bulkhead is saturated", name)
+		case <-time.After(20 * time.Millisecond):
+		}
+		require.Equal(t, 1, b.InFlight("billing"))
+
+		close(release)
+		for _, task := range []Task[string]{first, second, other} {
+			_, err := task.Await()
+			require.NoError(t, err)
+		}
+		require.Equal(t, 0, b.InFlight("billing"))
+	})
+
+	t.Run("full queue", func(t *testing.T) {
+		b := NewBulkhead(1, 0)
+		release, err := b.Acquire(nil, "billing")
+		require.NoError(t, err)
+		defer release()
+
+		called := false
+		_, err = NewTask(nil, func() (int, error) {
+			called = true
+			return 1, nil
+		}, WithBulkhead(b, "billing")).Await()
+		require.ErrorIs(t, err, ErrBulkheadFull)
+		require.False(t, called)
+	})
+
+	t.Run("cancelled while waiting", func(t *testing.T) {
+		cause := errors.New("caller went away")
+		b := NewBulkhead(1, 1)
+		release, err := b.Acquire(nil, "billing")
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancelCause(context.TODO())
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			cancel(cause)
+		}()
+		_, err = b.Acquire(ctx, "billing")
+		require.ErrorIs(t, err, cause)
+
+		release()
+		release()
+		require.Equal(t, 0, b.InFlight("billing"))
+	})
+	t.Run("timeout bounds waiting", func(t *testing.T) {
+		b := NewBulkhead(1, 1)
+		release, err := b.Acquire(nil, "billing")
+		require.NoError(t, err)
+		defer release()
+
+		called := false
+		task := NewTask(nil, func() (int, error) {
+			called = true
+			return 1, nil
+		}, WithBulkhead(b, "billing"), WithTimeout(20*time.Millisecond))
+
+		select {
+		case err := <-errorOf(task):
+			require.ErrorIs(t, err, ErrTaskTimeout)
+		case <-time.After(time.Second):
+			t.Fatal("task waiting for the bulkhead does not time out")
+		}
+		require.False(t, called)
+		require.Eventually(t, func() bool {
+			_, err := b.Acquire(ctxWithTimeout(t, time.Millisecond), "billing")
+			return !errors.Is(err, ErrBulkheadFull)
+		}, time.Second, time.Millisecond, "timed out task keeps its place in the queue")
+	})
+}
+
+func errorOf[T any](task Task[T]) <-chan error {
+	errs := make(chan error, 1)
+	go func() {
+		errs <- task.GetError()
+	}()
+
+	return errs
+}
+
+func ctxWithTimeout(t *testing.T, d time.Duration) context.Context {
+	ctx, cancel := context.WithTimeout(context.TODO(), d)
+	t.Cleanup(cancel)
+
+	return ctx
+}
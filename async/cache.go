@@ -0,0 +1,180 @@
+package async
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+type CacheOptions struct {
+	// TTL is how long loaded values are served, they never expire when it is not positive
+	TTL time.Duration
+	// MaxSize evicts the least recently used entries above it, the cache is unbounded when it is not positive
+	MaxSize int
+	// ErrorTTL is how long loader errors are served, they are not cached when it is not positive
+	ErrorTTL time.Duration
+	// StaleWhileRevalidate is how long expired values are still served while they are reloaded in the background
+	StaleWhileRevalidate time.Duration
+	Clock                Clock
+}
+
+// AsyncCache memoizes the results of loaders as tasks, concurrent loads of a key share a single task
+type AsyncCache[K comparable, V any] struct {
+	opts CacheOptions
+
+	mtx     sync.Mutex
+	entries map[K]*list.Element
+	lru     *list.List
+}
+
+type cacheEntry[K comparable, V any] struct {
+	key K
+	// load is the task loading the entry, nil when no load is in flight
+	load       Task[V]
+	settled    bool
+	value      V
+	err        error
+	expires    time.Time
+	staleUntil time.Time
+}
+
+func NewAsyncCache[K comparable, V any](opts CacheOptions) *AsyncCache[K, V] {
+	if opts.Clock == nil {
+		opts.Clock = globalClock()
+	}
+
+	return &AsyncCache[K, V]{
+		opts:    opts,
+		entries: make(map[K]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// Get returns the cached value of key, loading it with loader when it is missing or expired,
+// loads are not cancelled when ctx is done, only the returned task is
+func (c *AsyncCache[K, V]) Get(ctx context.Context, key K, loader func(ctx context.Context) (V, error), opts ...TaskOption) Task[V] {
+	if ctx == nil {
+		ctx = context.TODO()
+	}
+	if loader == nil {
+		return NewErrTask[V](ctx, ErrNilFuncEncountered)
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	now := c.opts.Clock.Now()
+	elem, ok := c.entries[key]
+	if !ok {
+		elem = c.lru.PushFront(&cacheEntry[K, V]{key: key})
+		c.entries[key] = elem
+		c.evict()
+	}
+	c.lru.MoveToFront(elem)
+
+	e := elem.Value.(*cacheEntry[K, V])
+	switch {
+	case e.settled && (e.expires.IsZero() || now.Before(e.expires)):
+		return e.resolved(ctx)
+	case e.settled && e.err == nil && now.Before(e.staleUntil):
+		if e.load == nil {
+			e.load = c.load(ctx, e, loader, opts)
+		}
+		return e.resolved(ctx)
+	}
+
+	if e.load == nil {
+		e.load = c.load(ctx, e, loader, opts)
+	}
+	load := e.load
+	opts = append([]TaskOption{withSpanLinks(load.GetContext())}, opts...)
+
+	return NewTask(ctx, func() (V, error) {
+		// a panic of the load is raised by the task of the caller, following its panic policy
+		return awaitSettled(load)
+	}, opts...)
+}
+
+// Invalidate drops key from the cache, loads of key in flight are not stored
+func (c *AsyncCache[K, V]) Invalidate(key K) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+}
+
+func (c *AsyncCache[K, V]) Len() int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return c.lru.Len()
+}
+
+// load starts loading e, it must be called with c.mtx locked
+func (c *AsyncCache[K, V]) load(ctx context.Context, e *cacheEntry[K, V], loader func(ctx context.Context) (V, error), opts []TaskOption) Task[V] {
+	loadCtx := context.WithoutCancel(ctx)
+	load := NewTask(loadCtx, func() (V, error) {
+		return loader(loadCtx)
+	}, opts...)
+	go c.settle(e, load)
+
+	return load
+}
+
+// settle stores the result of load in e unless e was evicted or invalidated meanwhile
+func (c *AsyncCache[K, V]) settle(e *cacheEntry[K, V], load Task[V]) {
+	data, err := awaitSettled(load)
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	elem, ok := c.entries[e.key]
+	if !ok || elem.Value != e || e.load != load {
+		return
+	}
+	e.load = nil
+
+	now := c.opts.Clock.Now()
+	switch {
+	case err == nil:
+		e.settled, e.value, e.err = true, data, nil
+		e.expires, e.staleUntil = time.Time{}, time.Time{}
+		if c.opts.TTL > 0 {
+			e.expires = now.Add(c.opts.TTL)
+			e.staleUntil = e.expires.Add(c.opts.StaleWhileRevalidate)
+		}
+	case e.settled && e.err == nil && now.Before(e.staleUntil):
+		// the stale value keeps being served when revalidating it fails
+	case c.opts.ErrorTTL > 0:
+		var zero V
+		e.settled, e.value, e.err = true, zero, err
+		e.expires = now.Add(c.opts.ErrorTTL)
+		e.staleUntil = e.expires
+	default:
+		c.remove(elem)
+	}
+}
+
+// evict must be called with c.mtx locked
+func (c *AsyncCache[K, V]) evict() {
+	for c.opts.MaxSize > 0 && c.lru.Len() > c.opts.MaxSize {
+		c.remove(c.lru.Back())
+	}
+}
+
+// remove must be called with c.mtx locked
+func (c *AsyncCache[K, V]) remove(elem *list.Element) {
+	c.lru.Remove(elem)
+	delete(c.entries, elem.Value.(*cacheEntry[K, V]).key)
+}
+
+func (e *cacheEntry[K, V]) resolved(ctx context.Context) Task[V] {
+	if e.err != nil {
+		return NewErrTask[V](ctx, e.err)
+	}
+
+	return Resolved(ctx, e.value)
+}
@@ -0,0 +1,189 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsyncCache_Get(t *testing.T) {
+	err := errors.New("i am error")
+
+	counting := func(calls *atomic.Int32, err error) func(ctx context.Context) (int, error) {
+		return func(ctx context.Context) (int, error) {
+			return int(calls.Add(1)), err
+		}
+	}
+	settled := func(t *testing.T, c *AsyncCache[string, int], key string) {
+		require.Eventually(t, func() bool {
+			c.mtx.Lock()
+			defer c.mtx.Unlock()
+
+			elem, ok := c.entries[key]
+			return !ok || elem.Value.(*cacheEntry[string, int]).load == nil
+		}, time.Second, time.Millisecond)
+	}
+	await := func(t *testing.T, task Task[int]) int {
+		result, err := task.Await()
+		require.NoError(t, err)
+		return result
+	}
+
+	t.Run("deduplicates concurrent loads", func(t *testing.T) {
+		c := NewAsyncCache[string, int](CacheOptions{})
+		var calls atomic.Int32
+		release := make(chan struct{})
+		loader := func(ctx context.Context) (int, error) {
+			<-release
+			return int(calls.Add(1)), nil
+		}
+
+		tasks := []Task[int]{c.Get(nil, "a", loader), c.Get(nil, "a", loader), c.Get(nil, "a", loader)}
+		close(release)
+		for _, task := range tasks {
+			require.Equal(t, 1, await(t, task))
+		}
+		require.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("expires after ttl", func(t *testing.T) {
+		clock := &steppedClock{Clock: SystemClock(), now: time.Now()}
+		c := NewAsyncCache[string, int](CacheOptions{TTL: time.Minute, Clock: clock})
+		var calls atomic.Int32
+
+		require.Equal(t, 1, await(t, c.Get(nil, "a", counting(&calls, nil))))
+		settled(t, c, "a")
+		require.Equal(t, 1, await(t, c.Get(nil, "a", counting(&calls, nil))))
+
+		clock.advance(time.Minute)
+		require.Equal(t, 2, await(t, c.Get(nil, "a", counting(&calls, nil))))
+	})
+
+	t.Run("evicts least recently used", func(t *testing.T) {
+		c := NewAsyncCache[string, int](CacheOptions{MaxSize: 2})
+		var calls atomic.Int32
+
+		for _, key := range []string{"a", "b", "a", "c"} {
+			await(t, c.Get(nil, key, counting(&calls, nil)))
+			settled(t, c, key)
+		}
+		require.Equal(t, 2, c.Len())
+		require.Equal(t, int32(3), calls.Load())
+
+		require.Equal(t, 1, await(t, c.Get(nil, "a", counting(&calls, nil))))
+		require.Equal(t, 4, await(t, c.Get(nil, "b", counting(&calls, nil))))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		var calls atomic.Int32
+
+		c := NewAsyncCache[string, int](CacheOptions{})
+		_, getErr := c.Get(nil, "a", counting(&calls, err)).Await()
+		require.ErrorIs(t, getErr, err)
+		settled(t, c, "a")
+		_, _ = c.Get(nil, "a", counting(&calls, err)).Await()
+		require.Equal(t, int32(2), calls.Load())
+
+		calls.Store(0)
+		c = NewAsyncCache[string, int](CacheOptions{ErrorTTL: time.Minute})
+		_, _ = c.Get(nil, "a", counting(&calls, err)).Await()
+		settled(t, c, "a")
+		_, getErr = c.Get(nil, "a", counting(&calls, err)).Await()
+		require.ErrorIs(t, getErr, err)
+		require.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("stale while revalidate", func(t *testing.T) {
+		clock := &steppedClock{Clock: SystemClock(), now: time.Now()}
+		c := NewAsyncCache[string, int](CacheOptions{TTL: time.Minute, StaleWhileRevalidate: time.Minute, Clock: clock})
+		var calls atomic.Int32
+
+		require.Equal(t, 1, await(t, c.Get(nil, "a", counting(&calls, nil))))
+		settled(t, c, "a")
+
+		clock.advance(90 * time.Second)
+		require.Equal(t, 1, await(t, c.Get(nil, "a", counting(&calls, nil))))
+		settled(t, c, "a")
+		require.Equal(t, 2, await(t, c.Get(nil, "a", counting(&calls, nil))))
+
+		clock.advance(3 * time.Minute)
+		require.Equal(t, 3, await(t, c.Get(nil, "a", counting(&calls, nil))))
+	})
+
+	t.Run("value loaded after a cached error does not expire", func(t *testing.T) {
+		clock := &steppedClock{Clock: SystemClock(), now: time.Now()}
+		c := NewAsyncCache[string, int](CacheOptions{ErrorTTL: time.Minute, Clock: clock})
+		var calls atomic.Int32
+
+		_, getErr := c.Get(nil, "a", counting(&calls, err)).Await()
+		require.ErrorIs(t, getErr, err)
+		settled(t, c, "a")
+
+		clock.advance(time.Minute)
+		for range 3 {
+			require.Equal(t, 2, await(t, c.Get(nil, "a", counting(&calls, nil))))
+			settled(t, c, "a")
+		}
+		require.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("failed revalidation keeps the stale value", func(t *testing.T) {
+		clock := &steppedClock{Clock: SystemClock(), now: time.Now()}
+		c := NewAsyncCache[string, int](CacheOptions{
+			TTL:                  time.Minute,
+			StaleWhileRevalidate: time.Minute,
+			ErrorTTL:             time.Minute,
+			Clock:                clock,
+		})
+		var calls atomic.Int32
+
+		require.Equal(t, 1, await(t, c.Get(nil, "a", counting(&calls, nil))))
+		settled(t, c, "a")
+
+		clock.advance(90 * time.Second)
+		require.Equal(t, 1, await(t, c.Get(nil, "a", counting(&calls, err))))
+		settled(t, c, "a")
+		require.Equal(t, 1, await(t, c.Get(nil, "a", counting(&calls, err))))
+		settled(t, c, "a")
+		require.Equal(t, int32(3), calls.Load())
+
+		clock.advance(time.Minute)
+		_, getErr := c.Get(nil, "a", counting(&calls, err)).Await()
+		require.ErrorIs(t, getErr, err)
+	})
+
+	t.Run("repanicking loader", func(t *testing.T) {
+		c := NewAsyncCache[string, int](CacheOptions{})
+
+		task := c.Get(nil, "a", func(ctx context.Context) (int, error) {
+			panic("boom")
+		}, WithPanicPolicy(PanicRepanic))
+
+		func() {
+			defer func() {
+				panicErr, ok := recover().(*PanicError)
+				require.True(t, ok)
+				require.Equal(t, "boom", panicErr.Value)
+			}()
+			_, _ = task.Await()
+			t.Error("the task of the caller did not panic")
+		}()
+		settled(t, c, "a")
+		require.Equal(t, 0, c.Len())
+	})
+
+	t.Run("invalidate", func(t *testing.T) {
+		c := NewAsyncCache[string, int](CacheOptions{})
+		var calls atomic.Int32
+
+		await(t, c.Get(nil, "a", counting(&calls, nil)))
+		settled(t, c, "a")
+		c.Invalidate("a")
+		require.Equal(t, 0, c.Len())
+		require.Equal(t, 2, await(t, c.Get(nil, "a", counting(&calls, nil))))
+	})
+}
@@ -0,0 +1,306 @@
+package async
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// CircuitOpenError is returned by calls rejected by an open CircuitBreaker, it matches ErrCircuitOpen
+type CircuitOpenError struct {
+	Name       string
+	RetryAfter time.Duration
+}
+
+func (e *CircuitOpenError) Error() string {
+	if e.Name == "" {
+		return fmt.Sprintf("%v, retry after %s", ErrCircuitOpen, e.RetryAfter)
+	}
+
+	return fmt.Sprintf("%v: %s, retry after %s", ErrCircuitOpen, e.Name, e.RetryAfter)
+}
+
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+type BreakerState int
+
+const (
+	BreakerClosed BreakerState = iota
+	BreakerOpen
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+
+	return fmt.Sprintf("BreakerState(%d)", int(s))
+}
+
+type BreakerSettings struct {
+	Name string
+	// Window is the number of latest calls the failure rate is computed over, 20 by default
+	Window int
+	// MinCalls is the number of calls required to open the breaker, Window by default
+	MinCalls int
+	// FailureRate opens the breaker when reached, 0.5 by default
+	FailureRate float64
+	// CoolDown is how long the breaker stays open before a trial call, 30 seconds by default
+	CoolDown time.Duration
+	// HalfOpenCalls is the number of successful trial calls required to close the breaker, 1 by default
+	HalfOpenCalls int
+	// IsFailure decides which errors count as failures, all of them by default
+	IsFailure     func(err error) bool
+	OnStateChange func(name string, from, to BreakerState)
+	Clock         Clock
+}
+
+type CircuitBreaker struct {
+	settings BreakerSettings
+
+	mtx        sync.Mutex
+	state      BreakerState
+	generation uint64
+	outcomes   []bool
+	next       int
+	calls      int
+	failures   int
+	openedAt   time.Time
+	trials     int
+	successes  int
+
+	// notifyMtx orders the state-change callbacks by the generation of their transitions
+	notifyMtx sync.Mutex
+	changes   map[uint64]breakerChange
+	notified  uint64
+	notifying bool
+}
+
+type breakerChange struct {
+	generation uint64
+	from, to   BreakerState
+}
+
+func NewCircuitBreaker(settings BreakerSettings) *CircuitBreaker {
+	if settings.Window <= 0 {
+		settings.Window = 20
+	}
+	if settings.MinCalls <= 0 || settings.MinCalls > settings.Window {
+		settings.MinCalls = settings.Window
+	}
+	if settings.FailureRate <= 0 {
+		settings.FailureRate = 0.5
+	}
+	if settings.CoolDown <= 0 {
+		settings.CoolDown = 30 * time.Second
+	}
+	if settings.HalfOpenCalls <= 0 {
+		settings.HalfOpenCalls = 1
+	}
+	if settings.IsFailure == nil {
+		settings.IsFailure = func(err error) bool {
+			return err != nil
+		}
+	}
+	if settings.Clock == nil {
+		settings.Clock = globalClock()
+	}
+
+	return &CircuitBreaker{
+		settings: settings,
+		outcomes: make([]bool, settings.Window),
+		changes:  make(map[uint64]breakerChange),
+		notified: 1,
+	}
+}
+
+// Protect returns f guarded by cb
+func Protect[T any](cb *CircuitBreaker, f func() (T, error)) func() (T, error) {
+	return func() (T, error) {
+		done, err := cb.allow()
+		if err != nil {
+			var zero T
+			return zero, err
+		}
+
+		defer func() {
+			if excp := recover(); excp != nil {
+				// a panicking call counts as a failure
+				done(newPanicError(excp, ""))
+				panic(excp)
+			}
+		}()
+
+		result, err := f()
+		done(err)
+
+		return result, err
+	}
+}
+
+// BreakerTask runs f guarded by cb, it is rejected with *CircuitOpenError without calling f when cb is open
+func BreakerTask[T any](ctx context.Context, cb *CircuitBreaker, f func() (T, error), opts ...TaskOption) Task[T] {
+	if f == nil {
+		return NewErrTask[T](ctx, ErrNilFuncEncountered)
+	}
+
+	return NewTask(ctx, Protect(cb, f), opts...)
+}
+
+func (cb *CircuitBreaker) State() BreakerState {
+	cb.mtx.Lock()
+	state, changed := cb.currentState(cb.settings.Clock.Now())
+	cb.mtx.Unlock()
+	cb.notify(changed)
+
+	return state
+}
+
+// allow reserves a call, done reports its result
+func (cb *CircuitBreaker) allow() (done func(err error), err error) {
+	cb.mtx.Lock()
+	now := cb.settings.Clock.Now()
+	state, changed := cb.currentState(now)
+	generation := cb.generation
+
+	switch {
+	case state == BreakerOpen:
+		err = &CircuitOpenError{
+			Name:       cb.settings.Name,
+			RetryAfter: cb.openedAt.Add(cb.settings.CoolDown).Sub(now),
+		}
+	case state == BreakerHalfOpen && cb.trials >= cb.settings.HalfOpenCalls:
+		err = &CircuitOpenError{
+			Name: cb.settings.Name,
+		}
+	case state == BreakerHalfOpen:
+		cb.trials++
+	}
+	cb.mtx.Unlock()
+	cb.notify(changed)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return func(err error) {
+		cb.record(generation, cb.settings.IsFailure(err))
+	}, nil
+}
+
+func (cb *CircuitBreaker) record(generation uint64, failed bool) {
+	cb.mtx.Lock()
+	if generation != cb.generation {
+		cb.mtx.Unlock()
+		return
+	}
+
+	var changed *breakerChange
+	now := cb.settings.Clock.Now()
+	switch cb.state {
+	case BreakerClosed:
+		if cb.calls == len(cb.outcomes) && cb.outcomes[cb.next] {
+			cb.failures--
+		}
+		if cb.calls < len(cb.outcomes) {
+			cb.calls++
+		}
+		cb.outcomes[cb.next] = failed
+		cb.next = (cb.next + 1) % len(cb.outcomes)
+		if failed {
+			cb.failures++
+		}
+		if cb.calls >= cb.settings.MinCalls && float64(cb.failures)/float64(cb.calls) >= cb.settings.FailureRate {
+			changed = cb.transition(BreakerOpen, now)
+		}
+	case BreakerHalfOpen:
+		if failed {
+			changed = cb.transition(BreakerOpen, now)
+			break
+		}
+		cb.successes++
+		if cb.successes >= cb.settings.HalfOpenCalls {
+			changed = cb.transition(BreakerClosed, now)
+		}
+	}
+	cb.mtx.Unlock()
+	cb.notify(changed)
+}
+
+// currentState moves an open breaker to half-open once cooled down, it must be called with cb.mtx locked
+func (cb *CircuitBreaker) currentState(now time.Time) (BreakerState, *breakerChange) {
+	if cb.state == BreakerOpen && !now.Before(cb.openedAt.Add(cb.settings.CoolDown)) {
+		return BreakerHalfOpen, cb.transition(BreakerHalfOpen, now)
+	}
+
+	return cb.state, nil
+}
+
+// transition returns the change of the state, it must be called with cb.mtx locked
+func (cb *CircuitBreaker) transition(to BreakerState, now time.Time) *breakerChange {
+	from := cb.state
+	cb.state = to
+	cb.generation++
+	cb.trials, cb.successes = 0, 0
+
+	switch to {
+	case BreakerOpen:
+		cb.openedAt = now
+	case BreakerClosed:
+		clear(cb.outcomes)
+		cb.next, cb.calls, cb.failures = 0, 0, 0
+	}
+
+	return &breakerChange{generation: cb.generation, from: from, to: to}
+}
+
+// notify calls OnStateChange for changed once the changes of the previous generations are delivered,
+// the goroutine delivering them also delivers the changes queued meanwhile
+func (cb *CircuitBreaker) notify(changed *breakerChange) {
+	if changed == nil || cb.settings.OnStateChange == nil {
+		return
+	}
+
+	cb.notifyMtx.Lock()
+	cb.changes[changed.generation] = *changed
+	if cb.notifying {
+		cb.notifyMtx.Unlock()
+		return
+	}
+	cb.notifying = true
+
+	done := false
+	defer func() {
+		if !done {
+			// OnStateChange panicked, the next notification takes over the delivery
+			cb.notifyMtx.Lock()
+			cb.notifying = false
+			cb.notifyMtx.Unlock()
+		}
+	}()
+
+	for {
+		change, ok := cb.changes[cb.notified]
+		if !ok {
+			cb.notifying = false
+			cb.notifyMtx.Unlock()
+			done = true
+			return
+		}
+		delete(cb.changes, cb.notified)
+		cb.notified++
+		cb.notifyMtx.Unlock()
+
+		cb.settings.OnStateChange(cb.settings.Name, change.from, change.to)
+		cb.notifyMtx.Lock()
+	}
+}
@@ -0,0 +1,196 @@
+package async
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// steppedClock reports a manually moved time and real timers
+type steppedClock struct {
+	Clock
+	mtx sync.Mutex
+	now time.Time
+}
+
+func (c *steppedClock) Now() time.Time {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return c.now
+}
+
+func (c *steppedClock) advance(d time.Duration) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	err := errors.New("i am error")
+	succeed := func() (int, error) {
+		return 1, nil
+	}
+	fail := func() (int, error) {
+		return 0, err
+	}
+
+	t.Run("opens on failure rate and fails fast", func(t *testing.T) {
+		clock := &steppedClock{Clock: SystemClock(), now: time.Now()}
+		cb := NewCircuitBreaker(BreakerSettings{Name: "billing", Window: 4, FailureRate: 0.5, CoolDown: time.Minute, Clock: clock})
+
+		for _, f := range []func() (int, error){succeed, fail, succeed} {
+			_, _ = BreakerTask(nil, cb, f).Await()
+		}
+		require.Equal(t, BreakerClosed, cb.State())
+
+		_, taskErr := BreakerTask(nil, cb, fail).Await()
+		require.ErrorIs(t, taskErr, err)
+		require.Equal(t, BreakerOpen, cb.State())
+
+		called := false
+		_, taskErr = BreakerTask(nil, cb, func() (int, error) {
+			called = true
+			return 1, nil
+		}).Await()
+		var openErr *CircuitOpenError
+		require.ErrorIs(t, taskErr, ErrCircuitOpen)
+		require.ErrorAs(t, taskErr, &openErr)
+		require.Equal(t, "billing", openErr.Name)
+		require.Equal(t, time.Minute, openErr.RetryAfter)
+		require.False(t, called)
+	})
+
+	t.Run("half-open trial closes or reopens", func(t *testing.T) {
+		clock := &steppedClock{Clock: SystemClock(), now: time.Now()}
+		var changes []string
+		cb := NewCircuitBreaker(BreakerSettings{
+			Window:   1,
+			CoolDown: time.Minute,
+			Clock:    clock,
+			OnStateChange: func(_ string, from, to BreakerState) {
+				changes = append(changes, from.String()+"->"+to.String())
+			},
+		})
+
+		_, _ = Protect(cb, fail)()
+		clock.advance(time.Minute)
+		require.Equal(t, BreakerHalfOpen, cb.State())
+
+		_, callErr := Protect(cb, fail)()
+		require.ErrorIs(t, callErr, err)
+		require.Equal(t, BreakerOpen, cb.State())
+
+		clock.advance(time.Minute)
+		result, callErr := Protect(cb, succeed)()
+		require.NoError(t, callErr)
+		require.Equal(t, 1, result)
+		require.Equal(t, BreakerClosed, cb.State())
+
+		require.Equal(t, []string{
+			"closed->open",
+			"open->half-open",
+			"half-open->open",
+			"open->half-open",
+			"half-open->closed",
+		}, changes)
+	})
+
+	t.Run("half-open limits trial calls", func(t *testing.T) {
+		clock := &steppedClock{Clock: SystemClock(), now: time.Now()}
+		cb := NewCircuitBreaker(BreakerSettings{Window: 1, CoolDown: time.Second, Clock: clock})
+
+		_, _ = Protect(cb, fail)()
+		clock.advance(time.Second)
+
+		started, release := make(chan struct{}), make(chan struct{})
+		trial := BreakerTask(nil, cb, func() (int, error) {
+			close(started)
+			<-release
+			return 1, nil
+		})
+		<-started
+		_, callErr := Protect(cb, succeed)()
+		require.ErrorIs(t, callErr, ErrCircuitOpen)
+
+		close(release)
+		_, taskErr := trial.Await()
+		require.NoError(t, taskErr)
+		require.Equal(t, BreakerClosed, cb.State())
+	})
+
+	t.Run("panicking trial reopens", func(t *testing.T) {
+		clock := &steppedClock{Clock: SystemClock(), now: time.Now()}
+		cb := NewCircuitBreaker(BreakerSettings{Window: 1, CoolDown: time.Minute, Clock: clock})
+
+		_, _ = Protect(cb, fail)()
+		clock.advance(time.Minute)
+
+		_, taskErr := BreakerTask(nil, cb, panickingFunction("boom")).Await()
+		var panicErr *PanicError
+		require.ErrorAs(t, taskErr, &panicErr)
+		require.Equal(t, BreakerOpen, cb.State())
+
+		clock.advance(time.Minute)
+		result, callErr := Protect(cb, succeed)()
+		require.NoError(t, callErr)
+		require.Equal(t, 1, result)
+		require.Equal(t, BreakerClosed, cb.State())
+	})
+
+	t.Run("state changes are delivered in order", func(t *testing.T) {
+		var (
+			mtx     sync.Mutex
+			changes [][2]BreakerState
+		)
+		cb := NewCircuitBreaker(BreakerSettings{
+			Window:   1,
+			CoolDown: time.Microsecond,
+			OnStateChange: func(_ string, from, to BreakerState) {
+				mtx.Lock()
+				defer mtx.Unlock()
+
+				changes = append(changes, [2]BreakerState{from, to})
+			},
+		})
+
+		var wg sync.WaitGroup
+		for i := range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				for j := range 200 {
+					if (i+j)%2 == 0 {
+						_, _ = Protect(cb, fail)()
+					} else {
+						_, _ = Protect(cb, succeed)()
+					}
+				}
+			}()
+		}
+		wg.Wait()
+
+		require.NotEmpty(t, changes)
+		require.Equal(t, BreakerClosed, changes[0][0])
+		for i := 1; i < len(changes); i++ {
+			require.Equal(t, changes[i-1][1], changes[i][0], "change %d is out of order", i)
+		}
+	})
+
+	t.Run("ignored errors", func(t *testing.T) {
+		cb := NewCircuitBreaker(BreakerSettings{
+			Window: 1,
+			IsFailure: func(callErr error) bool {
+				return callErr != nil && !errors.Is(callErr, err)
+			},
+		})
+
+		_, _ = Protect(cb, fail)()
+		require.Equal(t, BreakerClosed, cb.State())
+	})
+}
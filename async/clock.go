@@ -0,0 +1,117 @@
+package async
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// Clock is the source of time of timeouts, delays and intervals of tasks
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+}
+
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+}
+
+type systemClock struct{}
+
+type systemTimer struct {
+	timer *time.Timer
+}
+
+var defaultClock atomic.Pointer[Clock]
+
+func SystemClock() Clock {
+	return systemClock{}
+}
+
+// SetClock sets the clock of tasks created afterward, nil restores SystemClock
+func SetClock(clock Clock) {
+	if clock == nil {
+		defaultClock.Store(nil)
+		return
+	}
+	defaultClock.Store(&clock)
+}
+
+func WithClock(clock Clock) TaskOption {
+	return func(cfg *taskConfig) {
+		if clock != nil {
+			cfg.clock = clock
+		}
+	}
+}
+
+// WithTimeout resolves the task with ErrTaskTimeout when it runs longer than timeout on the task clock,
+// non-positive timeout restores the default
+func WithTimeout(timeout time.Duration) TaskOption {
+	return func(cfg *taskConfig) {
+		cfg.timeout = timeout
+	}
+}
+
+func globalClock() Clock {
+	if clock := defaultClock.Load(); clock != nil {
+		return *clock
+	}
+
+	return systemClock{}
+}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) NewTimer(d time.Duration) Timer {
+	return systemTimer{timer: time.NewTimer(d)}
+}
+
+func (t systemTimer) C() <-chan time.Time {
+	return t.timer.C
+}
+
+func (t systemTimer) Stop() bool {
+	return t.timer.Stop()
+}
+
+type clockCtxKey struct{}
+
+func contextWithClock(ctx context.Context, clock Clock) context.Context {
+	return context.WithValue(ctx, clockCtxKey{}, clock)
+}
+
+// clockFromContext returns the clock of the task intercepted with ctx, the global clock outside of interceptors
+func clockFromContext(ctx context.Context) Clock {
+	if clock, ok := ctx.Value(clockCtxKey{}).(Clock); ok {
+		return clock
+	}
+
+	return globalClock()
+}
+
+// sleep waits for d to pass on clock or ctx to be done
+func sleep(ctx context.Context, clock Clock, d time.Duration) error {
+	if ctx == nil {
+		ctx = context.TODO()
+	}
+	if d <= 0 {
+		if ctx.Err() != nil {
+			return context.Cause(ctx)
+		}
+		return nil
+	}
+
+	timer := clock.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C():
+		return nil
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	}
+}
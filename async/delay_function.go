@@ -0,0 +1,42 @@
+package async
+
+import (
+	"context"
+	"time"
+)
+
+// Delay resolves with data after d, or with the cause of the task context when it is done earlier
+func Delay[T any](ctx context.Context, d time.Duration, data T, opts ...TaskOption) Task[T] {
+	clock := newTaskConfig(opts).clock
+
+	return newTask(ctx, func(ctx context.Context) (T, error) {
+		if err := sleep(ctx, clock, d); err != nil {
+			var zero T
+			return zero, err
+		}
+
+		return data, nil
+	}, opts...)
+}
+
+func Sleep(ctx context.Context, d time.Duration, opts ...TaskOption) Task[struct{}] {
+	return Delay(ctx, d, struct{}{}, opts...)
+}
+
+// DelayTask resolves with the result of task, not earlier than after d
+func DelayTask[T any](ctx context.Context, d time.Duration, task Task[T], opts ...TaskOption) Task[T] {
+	if task == nil {
+		return NewErrTask[T](ctx, ErrNilValueEncountered)
+	}
+	clock := newTaskConfig(opts).clock
+	opts = append([]TaskOption{withSpanLinks(task.GetContext())}, opts...)
+
+	return newTask(ctx, func(ctx context.Context) (T, error) {
+		if err := sleep(ctx, clock, d); err != nil {
+			var zero T
+			return zero, err
+		}
+
+		return task.Await()
+	}, opts...)
+}
@@ -0,0 +1,76 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDelay(t *testing.T) {
+	start := time.Now()
+	result, err := Delay(nil, 20*time.Millisecond, 1).Await()
+
+	require.NoError(t, err)
+	require.Equal(t, 1, result)
+	require.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+}
+
+func TestDelay_Cancelled(t *testing.T) {
+	cause := errors.New("shutting down")
+	ctx, cancel := context.WithCancelCause(context.TODO())
+
+	tasks := []taskAny{
+		Delay(ctx, time.Hour, 1),
+		Sleep(ctx, time.Hour),
+		DelayTask(ctx, time.Hour, Resolved(nil, 1)),
+	}
+	time.AfterFunc(10*time.Millisecond, func() {
+		cancel(cause)
+	})
+
+	for _, task := range tasks {
+		err := task.GetError()
+		require.ErrorIs(t, err, cause)
+		require.ErrorIs(t, err, ErrTaskContextCancelled)
+	}
+}
+
+// liveTimersClock counts the timers that are neither fired nor stopped
+type liveTimersClock struct {
+	Clock
+	live atomic.Int32
+}
+
+type liveTimer struct {
+	Timer
+	clock *liveTimersClock
+	once  sync.Once
+}
+
+func (c *liveTimersClock) NewTimer(d time.Duration) Timer {
+	c.live.Add(1)
+	return &liveTimer{Timer: c.Clock.NewTimer(d), clock: c}
+}
+
+func (t *liveTimer) Stop() bool {
+	t.once.Do(func() {
+		t.clock.live.Add(-1)
+	})
+	return t.Timer.Stop()
+}
+
+func TestDelay_Timeout(t *testing.T) {
+	clock := &liveTimersClock{Clock: SystemClock()}
+	tsk := Delay(nil, time.Hour, 1, WithTimeout(10*time.Millisecond), WithClock(clock))
+
+	_, err := tsk.Await()
+
+	require.ErrorIs(t, err, ErrTaskTimeout)
+	require.Eventually(t, func() bool {
+		return clock.live.Load() == 0
+	}, time.Second, time.Millisecond, "delay keeps waiting after the task timed out")
+}
@@ -9,29 +9,40 @@ func Await(_ context.Context) {
 	runtime.Gosched()
 }
 
-func ForItem[T any](_ context.Context, s []T) <-chan T {
+// ForItem and other For producers stop and close the channel when ctx is done
+func ForItem[T any](ctx context.Context, s []T) <-chan T {
 	ch := make(chan T)
+	done := doneOf(ctx)
 
 	go func() {
+		defer close(ch)
+
 		for _, el := range s {
-			ch <- el
+			select {
+			case ch <- el:
+			case <-done:
+				return
+			}
 		}
-
-		close(ch)
 	}()
 
 	return ch
 }
 
-func ForIndex[T any](_ context.Context, s []T) <-chan int {
+func ForIndex[T any](ctx context.Context, s []T) <-chan int {
 	ch := make(chan int)
+	done := doneOf(ctx)
 
 	go func() {
+		defer close(ch)
+
 		for ind := range s {
-			ch <- ind
+			select {
+			case ch <- ind:
+			case <-done:
+				return
+			}
 		}
-
-		close(ch)
 	}()
 
 	return ch
@@ -47,64 +58,93 @@ type ForIterMap[T comparable, U any] struct {
 	Data U
 }
 
-func For[T any](_ context.Context, s []T) <-chan ForIter[T] {
+func For[T any](ctx context.Context, s []T) <-chan ForIter[T] {
 	ch := make(chan ForIter[T])
+	done := doneOf(ctx)
 
 	go func() {
+		defer close(ch)
+
 		for ind, el := range s {
-			ch <- ForIter[T]{
+			select {
+			case ch <- ForIter[T]{
 				Index: ind,
 				Data:  el,
+			}:
+			case <-done:
+				return
 			}
 		}
-
-		close(ch)
 	}()
 
 	return ch
 }
 
-func ForMap[T comparable, U any](_ context.Context, m map[T]U) <-chan ForIterMap[T, U] {
+func ForMap[T comparable, U any](ctx context.Context, m map[T]U) <-chan ForIterMap[T, U] {
 	ch := make(chan ForIterMap[T, U])
+	done := doneOf(ctx)
 
 	go func() {
+		defer close(ch)
+
 		for k, v := range m {
-			ch <- ForIterMap[T, U]{
+			select {
+			case ch <- ForIterMap[T, U]{
 				Key:  k,
 				Data: v,
+			}:
+			case <-done:
+				return
 			}
 		}
-
-		close(ch)
 	}()
 
 	return ch
 }
 
-func ForMapKey[T comparable, U any](_ context.Context, m map[T]U) <-chan T {
+func ForMapKey[T comparable, U any](ctx context.Context, m map[T]U) <-chan T {
 	ch := make(chan T)
+	done := doneOf(ctx)
 
 	go func() {
+		defer close(ch)
+
 		for k := range m {
-			ch <- k
+			select {
+			case ch <- k:
+			case <-done:
+				return
+			}
 		}
-
-		close(ch)
 	}()
 
 	return ch
 }
 
-func ForMapVal[T comparable, U any](_ context.Context, m map[T]U) <-chan U {
+func ForMapVal[T comparable, U any](ctx context.Context, m map[T]U) <-chan U {
 	ch := make(chan U)
+	done := doneOf(ctx)
 
 	go func() {
+		defer close(ch)
+
 		for _, v := range m {
-			ch <- v
+			select {
+			case ch <- v:
+			case <-done:
+				return
+			}
 		}
-
-		close(ch)
 	}()
 
 	return ch
 }
+
+// doneOf returns nil channel for nil ctx, receiving from it blocks forever
+func doneOf(ctx context.Context) <-chan struct{} {
+	if ctx == nil {
+		return nil
+	}
+
+	return ctx.Done()
+}
@@ -0,0 +1,98 @@
+package async
+
+import (
+	"context"
+	"sync"
+)
+
+// Group shares a single task between concurrent callers asking for the same key
+type Group[K comparable, T any] struct {
+	mtx   sync.Mutex
+	calls map[K]*groupCall[T]
+}
+
+type groupCall[T any] struct {
+	task   Task[T]
+	cancel context.CancelCauseFunc
+	refs   int
+	stops  []func() bool
+}
+
+// Do returns a task resolving with the result of f shared by the callers of key until it resolves,
+// f is cancelled only when the contexts of all of its callers are done
+func (g *Group[K, T]) Do(ctx context.Context, key K, f func(ctx context.Context) (T, error), opts ...TaskOption) Task[T] {
+	if ctx == nil {
+		ctx = context.TODO()
+	}
+	if f == nil {
+		return NewErrTask[T](ctx, ErrNilFuncEncountered)
+	}
+
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	if g.calls == nil {
+		g.calls = make(map[K]*groupCall[T])
+	}
+	call, ok := g.calls[key]
+	if !ok {
+		sharedCtx, cancel := context.WithCancelCause(context.WithoutCancel(ctx))
+		call = &groupCall[T]{cancel: cancel}
+		call.task = NewTask(sharedCtx, func() (T, error) {
+			return f(sharedCtx)
+		}, opts...)
+		g.calls[key] = call
+		go g.forget(key, call)
+	}
+
+	call.refs++
+	call.stops = append(call.stops, context.AfterFunc(ctx, func() {
+		g.release(key, call, ctx)
+	}))
+
+	shared := call.task
+	opts = append([]TaskOption{withSpanLinks(shared.GetContext())}, opts...)
+
+	return NewTask(ctx, func() (T, error) {
+		// a panic of the shared task is raised by the task of the caller, following its panic policy
+		return awaitSettled(shared)
+	}, opts...)
+}
+
+// Forget makes the next call of key start a new task even if the current one is still running
+func (g *Group[K, T]) Forget(key K) {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	delete(g.calls, key)
+}
+
+// release cancels the shared task when ctx was its last caller
+func (g *Group[K, T]) release(key K, call *groupCall[T], ctx context.Context) {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	call.refs--
+	if call.refs > 0 {
+		return
+	}
+	if g.calls[key] == call {
+		delete(g.calls, key)
+	}
+	call.cancel(context.Cause(ctx))
+}
+
+func (g *Group[K, T]) forget(key K, call *groupCall[T]) {
+	_, _ = awaitSettled(call.task)
+
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	if g.calls[key] == call {
+		delete(g.calls, key)
+	}
+	for _, stop := range call.stops {
+		stop()
+	}
+	call.cancel(nil)
+}
@@ -0,0 +1,131 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroup_Do(t *testing.T) {
+	t.Run("shares in-flight task", func(t *testing.T) {
+		var (
+			g     Group[string, int]
+			calls atomic.Int32
+		)
+		release := make(chan struct{})
+		f := func(ctx context.Context) (int, error) {
+			<-release
+			return int(calls.Add(1)), nil
+		}
+
+		tasks := []Task[int]{
+			g.Do(nil, "user:1", f),
+			g.Do(nil, "user:1", f),
+			g.Do(nil, "user:1", f),
+		}
+		close(release)
+		for _, task := range tasks {
+			result, err := task.Await()
+			require.NoError(t, err)
+			require.Equal(t, 1, result)
+		}
+		require.Equal(t, int32(1), calls.Load())
+
+		require.Eventually(t, func() bool {
+			result, err := g.Do(nil, "user:1", f).Await()
+			return err == nil && result == 2
+		}, time.Second, time.Millisecond)
+	})
+
+	t.Run("cancelled only when every caller is done", func(t *testing.T) {
+		var g Group[string, int]
+		cause := errors.New("caller went away")
+		sharedDone := make(chan error, 1)
+		release := make(chan struct{})
+		f := func(ctx context.Context) (int, error) {
+			select {
+			case <-ctx.Done():
+				sharedDone <- context.Cause(ctx)
+				return 0, context.Cause(ctx)
+			case <-release:
+				sharedDone <- nil
+				return 1, nil
+			}
+		}
+
+		ctx1, cancel1 := context.WithCancelCause(context.TODO())
+		ctx2, cancel2 := context.WithCancelCause(context.TODO())
+		first := g.Do(ctx1, "user:1", f)
+		second := g.Do(ctx2, "user:1", f)
+
+		cancel1(cause)
+		_, err := first.Await()
+		require.ErrorIs(t, err, ErrTaskContextCancelled)
+		select {
+		case <-sharedDone:
+			t.Fatal("shared task cancelled while a caller is waiting")
+		case <-time.After(20 * time.Millisecond):
+		}
+
+		cancel2(cause)
+		_, err = second.Await()
+		require.ErrorIs(t, err, ErrTaskContextCancelled)
+		select {
+		case err := <-sharedDone:
+			require.ErrorIs(t, err, cause)
+		case <-time.After(time.Second):
+			t.Fatal("shared task is not cancelled")
+		}
+		close(release)
+	})
+
+	t.Run("repanicking function", func(t *testing.T) {
+		var g Group[string, int]
+		task := g.Do(nil, "user:1", func(ctx context.Context) (int, error) {
+			panic("boom")
+		}, WithPanicPolicy(PanicRepanic))
+
+		func() {
+			defer func() {
+				panicErr, ok := recover().(*PanicError)
+				require.True(t, ok)
+				require.Equal(t, "boom", panicErr.Value)
+			}()
+			_ = task.GetError()
+			t.Error("the task of the caller did not panic")
+		}()
+		require.Eventually(t, func() bool {
+			g.mtx.Lock()
+			defer g.mtx.Unlock()
+
+			return len(g.calls) == 0
+		}, time.Second, time.Millisecond)
+	})
+
+	t.Run("caller options apply to the task of the caller", func(t *testing.T) {
+		var g Group[string, int]
+		release := make(chan struct{})
+		defer close(release)
+		f := func(ctx context.Context) (int, error) {
+			<-release
+			return 1, nil
+		}
+
+		first := g.Do(nil, "user:1", f)
+		second := g.Do(nil, "user:1", f, WithTimeout(10*time.Millisecond))
+
+		require.ErrorIs(t, second.GetError(), ErrTaskTimeout)
+		_, ok := TimingOf(first)
+		require.False(t, ok)
+	})
+
+	t.Run("nil function", func(t *testing.T) {
+		var g Group[string, int]
+		_, err := g.Do(nil, "user:1", nil).Await()
+		require.ErrorIs(t, err, ErrNilFuncEncountered)
+	})
+}
@@ -0,0 +1,83 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// Hedge starts another attempt of f every time the previous attempts do not complete within delay,
+// up to maxAttempts attempts. Failed attempts are replaced without waiting for delay.
+// It resolves with the first successful attempt and cancels the rest with ErrAttemptAbandoned
+func Hedge[T any](ctx context.Context, f func(ctx context.Context) (T, error), delay time.Duration, maxAttempts int, opts ...TaskOption) Task[T] {
+	if f == nil {
+		return NewErrTask[T](ctx, ErrNilFuncEncountered)
+	}
+	if ctx == nil {
+		ctx = context.TODO()
+	}
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	clock := newTaskConfig(opts).clock
+
+	var attempts atomic.Int32
+	opts = append([]TaskOption{withAttempts(&attempts)}, opts...)
+
+	return newTask(ctx, func(ctx context.Context) (result T, err error) {
+		hedgeCtx, cancel := context.WithCancelCause(ctx)
+		defer cancel(ErrAttemptAbandoned)
+
+		results := make(chan taskResult[T], maxAttempts)
+		launch := func() {
+			attempts.Add(1)
+			attempt := NewTask(hedgeCtx, func() (T, error) {
+				return f(hedgeCtx)
+			}, WithClock(clock))
+			go func() {
+				data, err := awaitSettled(attempt)
+				// the hedge reports the errors of its attempts as its own
+				var taskErr *TaskError
+				if errors.As(err, &taskErr) {
+					err = taskErr.Err
+				}
+				results <- taskResult[T]{data: data, err: err}
+			}()
+		}
+
+		launch()
+		timer := clock.NewTimer(delay)
+		defer func() {
+			timer.Stop()
+		}()
+
+		failed := 0
+		for {
+			select {
+			case res := <-results:
+				if res.err == nil {
+					return res.data, nil
+				}
+				failed++
+				err = res.err
+				if failed < int(attempts.Load()) {
+					continue
+				}
+				if int(attempts.Load()) == maxAttempts {
+					return result, err
+				}
+				launch()
+				timer.Stop()
+				timer = clock.NewTimer(delay)
+			case <-timer.C():
+				if int(attempts.Load()) < maxAttempts {
+					launch()
+					timer = clock.NewTimer(delay)
+				}
+			case <-ctx.Done():
+				return result, context.Cause(ctx)
+			}
+		}
+	}, opts...)
+}
@@ -0,0 +1,117 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHedge(t *testing.T) {
+	err := errors.New("i am error")
+
+	t.Run("first attempt succeeds", func(t *testing.T) {
+		var calls atomic.Int32
+		task := Hedge(nil, func(ctx context.Context) (int, error) {
+			return int(calls.Add(1)), nil
+		}, time.Hour, 3)
+
+		result, err := task.Await()
+
+		require.NoError(t, err)
+		require.Equal(t, 1, result)
+		require.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("backup attempt wins", func(t *testing.T) {
+		var calls atomic.Int32
+		abandoned := make(chan error, 1)
+		task := Hedge(nil, func(ctx context.Context) (int, error) {
+			attempt := calls.Add(1)
+			if attempt == 1 {
+				<-ctx.Done()
+				abandoned <- context.Cause(ctx)
+				return 0, ctx.Err()
+			}
+			return int(attempt), nil
+		}, 10*time.Millisecond, 3)
+
+		result, err := task.Await()
+
+		require.NoError(t, err)
+		require.Equal(t, 2, result)
+		require.Equal(t, ErrAttemptAbandoned, <-abandoned)
+	})
+
+	t.Run("failed attempts are replaced", func(t *testing.T) {
+		var calls atomic.Int32
+		task := Hedge(nil, func(ctx context.Context) (int, error) {
+			calls.Add(1)
+			return 0, err
+		}, time.Hour, 3)
+
+		_, hedgeErr := task.Await()
+
+		var taskErr *TaskError
+		require.ErrorIs(t, hedgeErr, err)
+		require.ErrorAs(t, hedgeErr, &taskErr)
+		require.Equal(t, 3, taskErr.Attempts)
+		require.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.TODO())
+		task := Hedge(ctx, func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}, time.Millisecond, 3)
+		time.AfterFunc(20*time.Millisecond, cancel)
+
+		require.ErrorIs(t, task.GetError(), ErrTaskContextCancelled)
+	})
+	t.Run("timed out", func(t *testing.T) {
+		abandoned := make(chan error, 1)
+		task := Hedge(nil, func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			abandoned <- context.Cause(ctx)
+			return 0, ctx.Err()
+		}, time.Hour, 3, WithTimeout(10*time.Millisecond))
+
+		require.ErrorIs(t, task.GetError(), ErrTaskTimeout)
+		select {
+		case cause := <-abandoned:
+			require.ErrorIs(t, cause, ErrTaskTimeout)
+		case <-time.After(time.Second):
+			t.Fatal("attempt keeps running after the task timed out")
+		}
+	})
+	t.Run("repanicking attempt", func(t *testing.T) {
+		SetPanicPolicy(PanicRepanic)
+		defer SetPanicPolicy(PanicConvert)
+
+		task := Hedge(nil, func(ctx context.Context) (int, error) {
+			panic("boom")
+		}, time.Hour, 2)
+
+		defer func() {
+			_, ok := recover().(*PanicError)
+			require.True(t, ok)
+		}()
+		_, _ = task.Await()
+		t.Error("await did not panic")
+	})
+	t.Run("caller options are left untouched", func(t *testing.T) {
+		opts := make([]TaskOption, 1, 2)
+		opts[0] = WithName("hedged")
+		spare := opts[:2]
+
+		_, hedgeErr := Hedge(nil, func(ctx context.Context) (int, error) {
+			return 1, nil
+		}, time.Hour, 1, opts...).Await()
+
+		require.NoError(t, hedgeErr)
+		require.Nil(t, spare[1])
+	})
+}
@@ -0,0 +1,75 @@
+package async
+
+import (
+	"context"
+	"time"
+)
+
+type LoopLimits struct {
+	// MaxIterations is ignored when zero
+	MaxIterations int
+	// Deadline is ignored when zero
+	Deadline time.Time
+	Interval time.Duration
+}
+
+func While[T any](ctx context.Context, cond func() bool, body func() (T, error), limits LoopLimits, opts ...TaskOption) Task[T] {
+	if cond == nil || body == nil {
+		return NewErrTask[T](ctx, ErrNilFuncEncountered)
+	}
+	clock := newTaskConfig(opts).clock
+
+	return newTask(ctx, func(ctx context.Context) (result T, err error) {
+		for i := 0; cond(); i++ {
+			if err = limits.wait(ctx, clock, i); err != nil {
+				return
+			}
+			result, err = body()
+			if err != nil {
+				return
+			}
+		}
+
+		return
+	}, opts...)
+}
+
+func Until[T any](ctx context.Context, body func() (T, error), done func(T) bool, limits LoopLimits, opts ...TaskOption) Task[T] {
+	if done == nil || body == nil {
+		return NewErrTask[T](ctx, ErrNilFuncEncountered)
+	}
+	clock := newTaskConfig(opts).clock
+
+	return newTask(ctx, func(ctx context.Context) (result T, err error) {
+		for i := 0; ; i++ {
+			if err = limits.wait(ctx, clock, i); err != nil {
+				return
+			}
+			result, err = body()
+			if err != nil || done(result) {
+				return
+			}
+		}
+	}, opts...)
+}
+
+func Poll[T any](ctx context.Context, f func() (T, error), interval time.Duration, done func(T) bool, opts ...TaskOption) Task[T] {
+	return Until(ctx, f, done, LoopLimits{Interval: interval}, opts...)
+}
+
+// wait blocks before the iteration-th run of the loop body
+func (l LoopLimits) wait(ctx context.Context, clock Clock, iteration int) error {
+	if l.MaxIterations > 0 && iteration >= l.MaxIterations {
+		return ErrLoopMaxIterations
+	}
+
+	var delay time.Duration
+	if iteration > 0 {
+		delay = l.Interval
+	}
+	if !l.Deadline.IsZero() && l.Deadline.Sub(clock.Now()) < delay {
+		return ErrLoopDeadline
+	}
+
+	return sleep(ctx, clock, delay)
+}
@@ -0,0 +1,232 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// instantClock fires timers as soon as they are created, moving its time forward by their duration
+type instantClock struct {
+	mtx sync.Mutex
+	now time.Time
+}
+
+type instantTimer chan time.Time
+
+func (c *instantClock) Now() time.Time {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return c.now
+}
+
+func (c *instantClock) NewTimer(d time.Duration) Timer {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.now = c.now.Add(d)
+	timer := make(instantTimer, 1)
+	timer <- c.now
+
+	return timer
+}
+
+func (t instantTimer) C() <-chan time.Time {
+	return t
+}
+
+func (t instantTimer) Stop() bool {
+	return false
+}
+
+func TestWhile(t *testing.T) {
+	err := errors.New("i am an error")
+	epoch := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name   string
+		stop   int
+		failAt int
+		limits LoopLimits
+		result int
+		err    error
+	}{
+		{
+			name:   "runs while condition holds",
+			stop:   5,
+			result: 5,
+			err:    nil,
+		},
+		{
+			name:   "condition false from the start",
+			stop:   0,
+			result: 0,
+			err:    nil,
+		},
+		{
+			name:   "body errors",
+			stop:   5,
+			failAt: 3,
+			result: 3,
+			err:    err,
+		},
+		{
+			name:   "max iterations exceeded",
+			stop:   5,
+			limits: LoopLimits{MaxIterations: 2},
+			result: 2,
+			err:    ErrLoopMaxIterations,
+		},
+		{
+			name:   "deadline exceeded",
+			stop:   100,
+			limits: LoopLimits{Interval: 10 * time.Second, Deadline: epoch.Add(25 * time.Second)},
+			result: 3,
+			err:    ErrLoopDeadline,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			counter := 0
+			tsk := While(nil, func() bool {
+				return counter < tc.stop
+			}, func() (int, error) {
+				counter++
+				if counter == tc.failAt {
+					return counter, err
+				}
+				return counter, nil
+			}, tc.limits, WithClock(&instantClock{now: epoch}))
+
+			result, err := tsk.Await()
+
+			require.Equal(t, tc.result, result)
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestUntil(t *testing.T) {
+	err := errors.New("i am an error")
+	testCases := []struct {
+		name   string
+		stop   int
+		failAt int
+		limits LoopLimits
+		result int
+		err    error
+	}{
+		{
+			name:   "runs until done",
+			stop:   5,
+			result: 5,
+			err:    nil,
+		},
+		{
+			name:   "body runs at least once",
+			stop:   0,
+			result: 1,
+			err:    nil,
+		},
+		{
+			name:   "body errors",
+			stop:   5,
+			failAt: 2,
+			result: 2,
+			err:    err,
+		},
+		{
+			name:   "max iterations exceeded",
+			stop:   5,
+			limits: LoopLimits{MaxIterations: 3},
+			result: 3,
+			err:    ErrLoopMaxIterations,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			counter := 0
+			tsk := Until(nil, func() (int, error) {
+				counter++
+				if counter == tc.failAt {
+					return counter, err
+				}
+				return counter, nil
+			}, func(i int) bool {
+				return i >= tc.stop
+			}, tc.limits)
+
+			result, err := tsk.Await()
+
+			require.Equal(t, tc.result, result)
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestPoll(t *testing.T) {
+	attempts := 0
+	status := func() (string, error) {
+		attempts++
+		if attempts < 3 {
+			return "pending", nil
+		}
+		return "done", nil
+	}
+
+	start := time.Now()
+	tsk := Poll(nil, status, 20*time.Millisecond, func(s string) bool {
+		return s == "done"
+	})
+	promised := FMap(nil, tsk, func(s string) (int, error) {
+		return len(s), nil
+	})
+
+	result, err := promised.Await()
+	dur := time.Since(start)
+
+	require.NoError(t, err)
+	require.Equal(t, len("done"), result)
+	require.Equal(t, 3, attempts)
+	if dur < 40*time.Millisecond {
+		t.Errorf("polled faster than interval: %d", dur.Milliseconds())
+	}
+}
+
+func TestPoll_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	tsk := Poll(ctx, func() (int, error) {
+		return 0, nil
+	}, time.Hour, func(int) bool {
+		return false
+	})
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	err := AreValid(nil, tsk)
+
+	require.ErrorIs(t, err, ErrTaskContextCancelled)
+}
+
+func TestPoll_Timeout(t *testing.T) {
+	var polls atomic.Int32
+	tsk := Poll(nil, func() (int, error) {
+		return int(polls.Add(1)), nil
+	}, 5*time.Millisecond, func(int) bool {
+		return false
+	}, WithTimeout(20*time.Millisecond))
+
+	_, err := tsk.Await()
+	require.ErrorIs(t, err, ErrTaskTimeout)
+	require.ErrorIs(t, context.Cause(tsk.GetContext()), ErrTaskTimeout)
+
+	time.Sleep(10 * time.Millisecond)
+	stopped := polls.Load()
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(t, stopped, polls.Load())
+}
@@ -0,0 +1,132 @@
+package async
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket refilled with rate tokens per second and holding up to burst tokens
+type RateLimiter struct {
+	rate  float64
+	burst float64
+	clock Clock
+
+	mtx    sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+type RateLimiterOption func(l *RateLimiter)
+
+func LimiterClock(clock Clock) RateLimiterOption {
+	return func(l *RateLimiter) {
+		if clock != nil {
+			l.clock = clock
+		}
+	}
+}
+
+// NewRateLimiter allows rate tasks per second with bursts of up to burst tasks, non-positive rate disables limiting
+func NewRateLimiter(rate float64, burst int, opts ...RateLimiterOption) *RateLimiter {
+	if burst <= 0 {
+		burst = 1
+	}
+
+	l := &RateLimiter{
+		rate:  rate,
+		burst: float64(burst),
+		clock: globalClock(),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(l)
+		}
+	}
+	l.tokens, l.last = l.burst, l.clock.Now()
+
+	return l
+}
+
+// WithRateLimiter delays the start of the task function until l has a token for it,
+// the time waited is reported by TimingOf and as TaskError.Waited
+func WithRateLimiter(l *RateLimiter) TaskOption {
+	return func(cfg *taskConfig) {
+		if l == nil {
+			return
+		}
+		cfg.gates = append(cfg.gates, func(ctx context.Context) (func(), error) {
+			return func() {}, l.Wait(ctx)
+		})
+	}
+}
+
+// Throttle returns f delayed until l has a token for it, it returns the cause of ctx when ctx is done first
+func Throttle[T any](ctx context.Context, l *RateLimiter, f func() (T, error)) func() (T, error) {
+	return func() (T, error) {
+		if err := l.Wait(ctx); err != nil {
+			var zero T
+			return zero, err
+		}
+
+		return f()
+	}
+}
+
+// Wait takes a token, waiting for it to be refilled when there is none
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.TODO()
+	}
+	if err := ctx.Err(); err != nil {
+		return context.Cause(ctx)
+	}
+
+	delay := l.reserve()
+	if err := sleep(ctx, l.clock, delay); err != nil {
+		l.cancel()
+		return err
+	}
+
+	return nil
+}
+
+// reserve takes a token ahead of time and returns how long to wait until it is refilled
+func (l *RateLimiter) reserve() time.Duration {
+	if l.rate <= 0 {
+		return 0
+	}
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	l.refill()
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// cancel gives back a token taken by reserve
+func (l *RateLimiter) cancel() {
+	if l.rate <= 0 {
+		return
+	}
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	l.refill()
+	l.tokens = min(l.tokens+1, l.burst)
+}
+
+// refill must be called with l.mtx locked
+func (l *RateLimiter) refill() {
+	now := l.clock.Now()
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens = min(l.tokens+elapsed.Seconds()*l.rate, l.burst)
+		l.last = now
+	}
+}
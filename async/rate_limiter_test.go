@@ -0,0 +1,94 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	err := errors.New("i am error")
+
+	t.Run("burst then rate", func(t *testing.T) {
+		l := NewRateLimiter(50, 2)
+
+		start := time.Now()
+		for range 3 {
+			require.NoError(t, l.Wait(nil))
+		}
+		require.GreaterOrEqual(t, time.Since(start), 15*time.Millisecond)
+	})
+
+	t.Run("waited reported by task error", func(t *testing.T) {
+		l := NewRateLimiter(50, 1)
+		require.NoError(t, l.Wait(nil))
+
+		_, taskErr := NewTask(nil, func() (int, error) {
+			return 0, err
+		}, WithRateLimiter(l)).Await()
+
+		var asTaskErr *TaskError
+		require.ErrorIs(t, taskErr, err)
+		require.ErrorAs(t, taskErr, &asTaskErr)
+		require.GreaterOrEqual(t, asTaskErr.Waited, 15*time.Millisecond)
+	})
+
+	t.Run("waited reported by successful task timing", func(t *testing.T) {
+		l := NewRateLimiter(50, 1)
+		require.NoError(t, l.Wait(nil))
+
+		task := NewTask(nil, func() (int, error) {
+			return 1, nil
+		}, WithRateLimiter(l))
+		_, ok := TimingOf(task)
+		require.False(t, ok)
+		_, taskErr := task.Await()
+		require.NoError(t, taskErr)
+
+		timing, ok := TimingOf(FutureOf(nil, task))
+		require.True(t, ok)
+		require.GreaterOrEqual(t, timing.Waited, 15*time.Millisecond)
+		require.GreaterOrEqual(t, timing.Duration(), timing.Waited)
+	})
+
+	t.Run("timeout bounds waiting", func(t *testing.T) {
+		l := NewRateLimiter(0.1, 1)
+		require.NoError(t, l.Wait(nil))
+
+		start := time.Now()
+		_, taskErr := NewTask(nil, func() (int, error) {
+			return 1, nil
+		}, WithRateLimiter(l), WithTimeout(20*time.Millisecond)).Await()
+
+		require.ErrorIs(t, taskErr, ErrTaskTimeout)
+		require.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("cancelled while waiting", func(t *testing.T) {
+		cause := errors.New("caller went away")
+		l := NewRateLimiter(0.1, 1)
+		require.NoError(t, l.Wait(nil))
+
+		ctx, cancel := context.WithCancelCause(context.TODO())
+		time.AfterFunc(10*time.Millisecond, func() {
+			cancel(cause)
+		})
+		called := false
+		_, callErr := Throttle(ctx, l, func() (int, error) {
+			called = true
+			return 1, nil
+		})()
+		require.ErrorIs(t, callErr, cause)
+		require.False(t, called)
+	})
+
+	t.Run("unlimited", func(t *testing.T) {
+		l := NewRateLimiter(0, 1)
+		for range 100 {
+			require.NoError(t, l.Wait(nil))
+		}
+	})
+}
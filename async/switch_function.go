@@ -0,0 +1,45 @@
+package async
+
+import "context"
+
+type Case[T any] struct {
+	When func() bool
+	Then func() (T, error)
+}
+
+func Switch[K comparable, T any](ctx context.Context, key K, branches map[K]func() (T, error), dflt func() (T, error), opts ...TaskOption) Task[T] {
+	branch, ok := branches[key]
+	switch {
+	case ok && branch == nil:
+		return NewErrTask[T](ctx, ErrNilFuncEncountered)
+	case !ok && dflt == nil:
+		return NewErrTask[T](ctx, ErrNoBranchMatched)
+	case !ok:
+		branch = dflt
+	}
+
+	return NewTask[T](ctx, branch, opts...)
+}
+
+func Match[T any](ctx context.Context, cases []Case[T], dflt func() (T, error), opts ...TaskOption) Task[T] {
+	return NewTask[T](ctx, func() (T, error) {
+		for _, c := range cases {
+			if c.When == nil || !c.When() {
+				continue
+			}
+			if c.Then == nil {
+				var zero T
+				return zero, ErrNilFuncEncountered
+			}
+
+			return c.Then()
+		}
+
+		if dflt == nil {
+			var zero T
+			return zero, ErrNoBranchMatched
+		}
+
+		return dflt()
+	}, opts...)
+}
@@ -0,0 +1,160 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSwitch(t *testing.T) {
+	ctx := context.TODO()
+	err := errors.New("i am an error")
+	notSelected := func() (int, error) {
+		t.Error("branch should not run")
+		return 0, nil
+	}
+	testCases := []struct {
+		name     string
+		key      string
+		branches map[string]func() (int, error)
+		dflt     func() (int, error)
+		result   int
+		err      error
+	}{
+		{
+			name: "matching branch runs",
+			key:  "b",
+			branches: map[string]func() (int, error){
+				"a": notSelected,
+				"b": func() (int, error) {
+					return 2, nil
+				},
+			},
+			dflt:   notSelected,
+			result: 2,
+			err:    nil,
+		},
+		{
+			name: "matching branch errors",
+			key:  "a",
+			branches: map[string]func() (int, error){
+				"a": func() (int, error) {
+					return 1, err
+				},
+			},
+			dflt:   notSelected,
+			result: 1,
+			err:    err,
+		},
+		{
+			name: "default runs on missing key",
+			key:  "c",
+			branches: map[string]func() (int, error){
+				"a": notSelected,
+			},
+			dflt: func() (int, error) {
+				return 3, nil
+			},
+			result: 3,
+			err:    nil,
+		},
+		{
+			name: "no default on missing key",
+			key:  "c",
+			branches: map[string]func() (int, error){
+				"a": notSelected,
+			},
+			result: 0,
+			err:    ErrNoBranchMatched,
+		},
+		{
+			name: "nil branch on matching key",
+			key:  "a",
+			branches: map[string]func() (int, error){
+				"a": nil,
+			},
+			dflt:   notSelected,
+			result: 0,
+			err:    ErrNilFuncEncountered,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tsk := Switch(ctx, tc.key, tc.branches, tc.dflt)
+
+			result, err := tsk.Await()
+
+			require.Equal(t, result, tc.result)
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	ctx := context.TODO()
+	err := errors.New("i am an error")
+	yes := func() bool { return true }
+	no := func() bool { return false }
+	notSelected := func() (int, error) {
+		t.Error("branch should not run")
+		return 0, nil
+	}
+	testCases := []struct {
+		name   string
+		cases  []Case[int]
+		dflt   func() (int, error)
+		result int
+		err    error
+	}{
+		{
+			name: "first matching case runs",
+			cases: []Case[int]{
+				{When: no, Then: notSelected},
+				{When: yes, Then: func() (int, error) { return 2, nil }},
+				{When: yes, Then: notSelected},
+			},
+			dflt:   notSelected,
+			result: 2,
+			err:    nil,
+		},
+		{
+			name: "matching case errors",
+			cases: []Case[int]{
+				{When: yes, Then: func() (int, error) { return 1, err }},
+			},
+			dflt:   notSelected,
+			result: 1,
+			err:    err,
+		},
+		{
+			name: "default runs when nothing matches",
+			cases: []Case[int]{
+				{When: no, Then: notSelected},
+			},
+			dflt:   func() (int, error) { return 3, nil },
+			result: 3,
+			err:    nil,
+		},
+		{
+			name: "no default when nothing matches",
+			cases: []Case[int]{
+				{When: no, Then: notSelected},
+			},
+			result: 0,
+			err:    ErrNoBranchMatched,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tsk := Match(ctx, tc.cases, tc.dflt)
+
+			result, err := tsk.Await()
+
+			require.Equal(t, result, tc.result)
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
@@ -13,6 +13,14 @@ var (
 	ErrTaskContextCancelled = context.Canceled
 	ErrNilValueEncountered  = errors.New("null value encountered")
 	ErrNilFuncEncountered   = errors.New("null value encountered")
+	ErrNoBranchMatched      = errors.New("no branch matched")
+	ErrLoopMaxIterations    = errors.New("loop exceeded max iterations")
+	ErrLoopDeadline         = errors.New("loop exceeded its deadline")
+	ErrAttemptAbandoned     = errors.New("attempt abandoned after another attempt completed")
+	ErrCircuitOpen          = errors.New("circuit breaker is open")
+	ErrBulkheadFull         = errors.New("bulkhead is full")
+	ErrBatchKeyMissing      = errors.New("batch function returned no value for key")
+	ErrInterceptorResult    = errors.New("interceptor returned a result of another type than the task")
 )
 
 const (
@@ -6,102 +6,255 @@ import (
 	"fmt"
 	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 type task[T any] struct {
 	retriever func() (T, error)
 	ctx       context.Context
+	cfg       *taskConfig
+	taskInfo  TaskInfo
+	span      Span
+	labels    context.Context
+	timings   *atomic.Pointer[TaskTiming]
 }
 
-func NewTask[T any](ctx context.Context, f func() (T, error)) Task[T] {
+func NewTask[T any](ctx context.Context, f func() (T, error), opts ...TaskOption) Task[T] {
+	return newTask(ctx, func(context.Context) (T, error) {
+		return f()
+	}, opts...)
+}
+
+// newTask runs f with the context of the task, it is cancelled when the task resolves with an error or times out
+func newTask[T any](ctx context.Context, f func(ctx context.Context) (T, error), opts ...TaskOption) Task[T] {
 	if ctx == nil {
 		ctx = context.TODO()
 	}
 
+	cfg := newTaskConfig(opts)
+	taskInfo := newTaskInfo(ctx, cfg)
+	labels := profilerLabels(ctx, taskInfo)
+	ctx, span := startSpan(ctx, cfg, taskInfo)
+	ctx = contextWithTaskInfo(ctx, taskInfo)
+	if cfg.registry != nil {
+		cfg.registry.register(taskInfo)
+	}
+
 	var cancelFnx context.CancelCauseFunc
 	ctx, cancelFnx = context.WithCancelCause(ctx)
-	once := task[T]{}.createOnceFunc(ctx, cancelFnx, f)
-	go once()
+	timings := new(atomic.Pointer[TaskTiming])
+	once := task[T]{cfg: cfg, taskInfo: taskInfo, span: span, labels: labels, timings: timings}.createOnceFunc(ctx, cancelFnx, func() (T, error) {
+		return f(ctx)
+	})
+	goLabeled(labels, func() {
+		_, _ = once()
+	})
 	runtime.Gosched()
 
 	return &task[T]{
 		retriever: once,
 		ctx:       ctx,
+		cfg:       cfg,
+		taskInfo:  taskInfo,
+		span:      span,
+		labels:    labels,
+		timings:   timings,
 	}
 }
 
 func (t task[T]) createOnceFunc(ctx context.Context, cancelFnx context.CancelCauseFunc, f func() (T, error)) func() (T, error) {
 	once := sync.OnceValues(func() (T, error) {
-		funcRes := make(chan *taskResult[T])
-		doneRes := make(chan *taskResult[T])
-
-		go func() {
-			var (
-				data T
-				err  error
-			)
-			defer func() {
-				if excp := recover(); excp != nil {
-					switch v := excp.(type) {
-					case string:
-						err = fmt.Errorf("task panic'd: %s", v)
-					case error:
-						err = fmt.Errorf("task panic'd: %w", v)
-					default:
-						err = errors.New(fmt.Sprint(v))
-					}
-				}
+		start := t.cfg.clock.Now()
+		if t.cfg.metrics != nil {
+			t.cfg.metrics.TaskStarted(t.taskInfo.Name)
+		}
+		logTaskStart(ctx, t.cfg.logger, t.taskInfo)
 
-				funcRes <- &taskResult[T]{
-					data: data,
-					err:  err,
+		var (
+			executed atomic.Bool
+			waited   atomic.Int64
+		)
+		data, err := t.intercept(ctx, func() (any, error) {
+			if ctx.Err() != nil {
+				// the function is not started for tasks that are cancelled beforehand
+				return nil, ErrTaskContextCancelled
+			}
+			executed.Store(true)
+			// the gates are passed within execute so that the timeout of the task bounds waiting for them
+			result := t.execute(ctx, cancelFnx, func() (T, error) {
+				entered := t.cfg.clock.Now()
+				release, err := t.cfg.enter(ctx)
+				waited.Store(int64(t.cfg.clock.Now().Sub(entered)))
+				if err != nil {
+					var zero T
+					return zero, err
 				}
-			}()
+				defer release()
 
-			data, err = f()
-		}()
+				return f()
+			})
+			return result.data, result.err
+		})
+		if t.cfg.registry != nil {
+			if executed.Load() {
+				t.cfg.registry.resolved(t.taskInfo.ID)
+			} else {
+				t.cfg.registry.returned(t.taskInfo.ID)
+			}
+		}
 
-		go func() {
-			select {
-			case result := <-funcRes:
-				doneRes <- result
-				if result.err != nil {
-					cancelFnx(result.err)
-				}
-			case <-time.After(defaultTimeout):
-				doneRes <- &taskResult[T]{
-					err: ErrTaskTimeout,
-				}
-			case <-ctx.Done():
-				doneRes <- &taskResult[T]{
-					err: ErrTaskContextCancelled,
-				}
+		end := t.cfg.clock.Now()
+		t.timings.Store(&TaskTiming{Start: start, End: end, Waited: time.Duration(waited.Load())})
+		outcome, elapsed := outcomeOf(err), end.Sub(start)
+		if t.cfg.metrics != nil {
+			t.cfg.metrics.TaskFinished(t.taskInfo.Name, outcome, elapsed)
+		}
+		logTaskFinish(ctx, t.cfg.logger, t.taskInfo, outcome, err, elapsed, time.Duration(waited.Load()))
+		endSpan(t.span, outcome, err)
+		var parentErr *TaskError
+		if err != nil && errors.As(err, &parentErr) {
+			// errors of awaited tasks keep identifying the task that failed first
+			return data, err
+		}
+		if err != nil {
+			taskErr := newTaskError(ctx, t.taskInfo, start, end, err)
+			if t.cfg.attempts != nil {
+				taskErr.Attempts = int(t.cfg.attempts.Load())
+			}
+			taskErr.Waited = time.Duration(waited.Load())
+			return data, taskErr
+		}
+
+		return data, nil
+	})
+
+	return once
+}
+
+// intercept runs execution through the interceptor chain of the task
+func (t task[T]) intercept(ctx context.Context, execution func() (any, error)) (data T, err error) {
+	defer func() {
+		if excp := recover(); excp != nil {
+			err = t.recovered(excp)
+		}
+	}()
+
+	ctx = contextWithClock(ctx, t.cfg.clock)
+	result, err := chainInterceptors(ctx, t.taskInfo, t.cfg.interceptors, execution)()
+	if result == nil {
+		return data, err
+	}
+	data, ok := result.(T)
+	if !ok && err == nil {
+		err = fmt.Errorf("%w: got %T, want %T", ErrInterceptorResult, result, data)
+	}
+
+	return data, err
+}
+
+func (t task[T]) execute(ctx context.Context, cancelFnx context.CancelCauseFunc, f func() (T, error)) *taskResult[T] {
+	// funcRes is buffered so that f does not block when the task is resolved before f returns
+	funcRes := make(chan *taskResult[T], 1)
+	doneRes := make(chan *taskResult[T])
+
+	goLabeled(t.labels, func() {
+		var (
+			data T
+			err  error
+		)
+		defer func() {
+			if excp := recover(); excp != nil {
+				err = t.recovered(excp)
+			}
+
+			if t.cfg.registry != nil {
+				t.cfg.registry.returned(t.taskInfo.ID)
+			}
+			funcRes <- &taskResult[T]{
+				data: data,
+				err:  err,
 			}
 		}()
 
-		result := <-doneRes
+		data, err = f()
+	})
+
+	goLabeled(t.labels, func() {
+		timer := t.cfg.watchdog()
+		defer timer.Stop()
 
-		return result.data, result.err
+		// the context is cancelled before the task resolves, so that its cause is set once the task is awaited
+		select {
+		case result := <-funcRes:
+			if result.err != nil {
+				cancelFnx(result.err)
+			}
+			doneRes <- result
+		case <-timer.C():
+			cancelFnx(ErrTaskTimeout)
+			doneRes <- &taskResult[T]{
+				err: ErrTaskTimeout,
+			}
+		case <-ctx.Done():
+			doneRes <- &taskResult[T]{
+				err: ErrTaskContextCancelled,
+			}
+		}
 	})
 
-	return once
+	return <-doneRes
+}
+
+func (t task[T]) recovered(excp any) error {
+	panicErr := newPanicError(excp, t.cfg.name)
+	handlePanic(t.cfg.panicPolicy, panicErr)
+
+	return panicErr
 }
 
 func (t *task[T]) Await() (T, error) {
 	runtime.Gosched()
+	return t.result()
+}
+
+func (t *task[T]) result() (T, error) {
+	data, err := t.settle()
+	repanicOnError(t.cfg.panicPolicy, err)
+
+	return data, err
+}
+
+func (t *task[T]) settle() (T, error) {
+	if t.cfg.registry != nil {
+		t.cfg.registry.awaited(t.taskInfo.ID)
+	}
+
 	return t.retriever()
 }
 
+// Subscribe calls cb on a new goroutine, under PanicRepanic it gets *PanicError as its error instead of panicking there
 func (t *task[T]) Subscribe(cb func(data T, err error)) {
-	go cb(t.Await())
+	go cb(t.settle())
 }
 
 func (t *task[T]) GetContext() context.Context {
 	return t.ctx
 }
 
+func (t *task[T]) timing() (TaskTiming, bool) {
+	if timing := t.timings.Load(); timing != nil {
+		return *timing, true
+	}
+
+	return TaskTiming{}, false
+}
+
+func (t *task[T]) info() TaskInfo {
+	return t.taskInfo
+}
+
 func (t *task[T]) GetError() error {
-	_, err := t.retriever()
+	_, err := t.result()
 	return err
 }
@@ -66,7 +66,7 @@ func TestTask_Await(t *testing.T) {
 			task := NewTask(tc.ctx, tc.f)
 			result, err := task.Await()
 			require.Equal(t, result, tc.expected)
-			require.Equal(t, err, tc.err)
+			require.ErrorIs(t, err, tc.err)
 		})
 	}
 }
@@ -126,46 +126,12 @@ func TestTask_Subscribe(t *testing.T) {
 			task := NewTask(tc.ctx, tc.f)
 			task.Subscribe(func(result int, err error) {
 				require.Equal(t, result, tc.expected)
-				require.Equal(t, err, tc.err)
+				require.ErrorIs(t, err, tc.err)
 			})
 		})
 	}
 }
 
-func TestTask_MultipleTasks(t *testing.T) {
-	result := 1
-
-	longFunction := func() (int, error) {
-		time.Sleep(time.Millisecond * 500)
-		return result, nil
-	}
-
-	tasks := append([]Task[int]{},
-		NewTask(nil, longFunction),
-		NewTask(nil, longFunction),
-		NewTask(nil, longFunction),
-		NewTask(nil, longFunction),
-		NewTask(nil, longFunction),
-		NewTask(nil, longFunction),
-		NewTask(nil, longFunction),
-		NewTask(nil, longFunction),
-		NewTask(nil, longFunction),
-		NewTask(nil, longFunction),
-	)
-
-	start := time.Now()
-	for _, task := range tasks {
-		res, err := task.Await()
-
-		require.Equal(t, err, nil)
-		require.Equal(t, res, result)
-	}
-	dur := time.Since(start)
-	if dur.Milliseconds() > 550 {
-		t.Errorf("took longer than 500ms")
-	}
-}
-
 func TestTask_ConcurrentCalls(t *testing.T) {
 	result := 1
 
@@ -1,6 +1,12 @@
 package async
 
-import "context"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
 
 type taskErr[T any] struct {
 	ctx  context.Context
@@ -9,6 +15,10 @@ type taskErr[T any] struct {
 }
 
 func NewErrTask[T any](ctx context.Context, err error) Task[T] {
+	if ctx == nil {
+		ctx = context.TODO()
+	}
+
 	return &taskErr[T]{
 		ctx: ctx,
 		err: err,
@@ -30,3 +40,63 @@ func (t *taskErr[T]) GetContext() context.Context {
 func (t *taskErr[T]) GetError() error {
 	return t.err
 }
+
+// TaskError wraps errors returned by tasks with the identity and timing of the task.
+// Errors that already carry a TaskError, such as the errors of awaited tasks, are not wrapped again
+type TaskError struct {
+	TaskID   uint64
+	TaskName string
+	Start    time.Time
+	End      time.Time
+	Attempts int
+	// Waited is how long the task waited for its rate limiters and bulkheads before its function started
+	Waited time.Duration
+	// Err is the error the task resolved with
+	Err error
+	// Cause is context.Cause of the task context when it differs from Err
+	Cause error
+}
+
+func newTaskError(ctx context.Context, info TaskInfo, start, end time.Time, err error) *TaskError {
+	taskErr := &TaskError{
+		TaskID:   info.ID,
+		TaskName: info.Name,
+		Start:    start,
+		End:      end,
+		Attempts: 1,
+		Err:      err,
+	}
+	if ctx != nil {
+		if cause := context.Cause(ctx); cause != nil && !errors.Is(err, cause) {
+			taskErr.Cause = cause
+		}
+	}
+
+	return taskErr
+}
+
+func (e *TaskError) Error() string {
+	var sb strings.Builder
+	sb.WriteString("task ")
+	if e.TaskName != "" {
+		fmt.Fprintf(&sb, "%q ", e.TaskName)
+	}
+	fmt.Fprintf(&sb, "#%d failed after %s: %v", e.TaskID, e.Duration(), e.Err)
+	if e.Cause != nil {
+		fmt.Fprintf(&sb, " (cause: %v)", e.Cause)
+	}
+
+	return sb.String()
+}
+
+func (e *TaskError) Unwrap() []error {
+	if e.Cause == nil {
+		return []error{e.Err}
+	}
+
+	return []error{e.Err, e.Cause}
+}
+
+func (e *TaskError) Duration() time.Duration {
+	return e.End.Sub(e.Start)
+}
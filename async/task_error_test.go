@@ -0,0 +1,89 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestTaskError(t *testing.T) {
+	err := errors.New("i am error")
+
+	t.Run("task identity and timing", func(t *testing.T) {
+		task := NewTask(nil, func() (int, error) {
+			time.Sleep(10 * time.Millisecond)
+			return 0, err
+		}, WithName("loader"))
+
+		_, awaitErr := task.Await()
+
+		var taskErr *TaskError
+		require.ErrorAs(t, awaitErr, &taskErr)
+		require.ErrorIs(t, awaitErr, err)
+		require.Equal(t, "loader", taskErr.TaskName)
+		require.NotZero(t, taskErr.TaskID)
+		require.Equal(t, 1, taskErr.Attempts)
+		require.GreaterOrEqual(t, taskErr.Duration(), 10*time.Millisecond)
+		require.Nil(t, taskErr.Cause)
+	})
+
+	t.Run("cancellation cause", func(t *testing.T) {
+		ctx, cancel := context.WithCancelCause(context.TODO())
+		task := NewTask(ctx, func() (int, error) {
+			time.Sleep(time.Second)
+			return 0, nil
+		})
+		cancel(err)
+
+		_, awaitErr := task.Await()
+
+		var taskErr *TaskError
+		require.ErrorAs(t, awaitErr, &taskErr)
+		require.ErrorIs(t, awaitErr, ErrTaskContextCancelled)
+		require.ErrorIs(t, awaitErr, err)
+		require.Equal(t, err, taskErr.Cause)
+	})
+
+	t.Run("promised task wraps parent error", func(t *testing.T) {
+		task := NewTask(nil, func() (int, error) {
+			return 0, err
+		}, WithName("parent"))
+		_ = task.GetError()
+		promised := FMap(nil, task, func(i int) (int, error) {
+			return i, nil
+		}, WithName("child"))
+
+		_, awaitErr := promised.Await()
+
+		var taskErr *TaskError
+		require.ErrorAs(t, awaitErr, &taskErr)
+		require.Equal(t, "child", taskErr.TaskName)
+		require.ErrorIs(t, awaitErr, err)
+	})
+
+	t.Run("awaiting task keeps parent TaskError", func(t *testing.T) {
+		parent := NewTask(nil, func() (int, error) {
+			return 0, err
+		}, WithName("parent"))
+		child := NewTask(nil, func() (int, error) {
+			return parent.Await()
+		}, WithName("child"))
+
+		_, awaitErr := child.Await()
+
+		var taskErr *TaskError
+		require.ErrorAs(t, awaitErr, &taskErr)
+		require.Equal(t, "parent", taskErr.TaskName)
+		require.Equal(t, err, taskErr.Err)
+	})
+
+	t.Run("validation wraps plain errors", func(t *testing.T) {
+		validationErr := AreValid(nil, NewErrTask[int](nil, err))
+
+		var taskErr *TaskError
+		require.ErrorAs(t, validationErr, &taskErr)
+		require.ErrorIs(t, validationErr, err)
+	})
+}
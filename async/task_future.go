@@ -0,0 +1,125 @@
+package async
+
+import "context"
+
+// Future is a Task with same-type chaining methods.
+// Every method returns a new Future and leaves the receiver unchanged
+type Future[T any] struct {
+	ctx  context.Context
+	task Task[T]
+}
+
+func NewFuture[T any](ctx context.Context, f func() (T, error), opts ...TaskOption) *Future[T] {
+	if ctx == nil {
+		ctx = context.TODO()
+	}
+
+	return &Future[T]{
+		ctx:  ctx,
+		task: NewTask(ctx, f, opts...),
+	}
+}
+
+// FutureOf wraps task, the tasks chained to the future are created with ctx
+func FutureOf[T any](ctx context.Context, task Task[T]) *Future[T] {
+	if ctx == nil {
+		ctx = context.TODO()
+	}
+	if task == nil {
+		task = NewErrTask[T](ctx, ErrNilValueEncountered)
+	}
+
+	return &Future[T]{
+		ctx:  ctx,
+		task: task,
+	}
+}
+
+// Then maps the result of the future, it is not called when the future errors
+func (f *Future[T]) Then(mapper func(data T) (T, error), opts ...TaskOption) *Future[T] {
+	return f.chain(func(data T, err error) (T, error) {
+		if err != nil {
+			return data, err
+		}
+		return mapper(data)
+	}, opts)
+}
+
+// Catch recovers from the error of the future, it is not called when the future succeeds
+func (f *Future[T]) Catch(handler func(err error) (T, error), opts ...TaskOption) *Future[T] {
+	return f.chain(func(data T, err error) (T, error) {
+		if err == nil {
+			return data, nil
+		}
+		return handler(err)
+	}, opts)
+}
+
+// Finally is called when the future is resolved, the result is passed through
+func (f *Future[T]) Finally(cb func(), opts ...TaskOption) *Future[T] {
+	return f.chain(func(data T, err error) (T, error) {
+		cb()
+		return data, err
+	}, opts)
+}
+
+func (f *Future[T]) OnSuccess(cb func(data T), opts ...TaskOption) *Future[T] {
+	return f.chain(func(data T, err error) (T, error) {
+		if err == nil {
+			cb(data)
+		}
+		return data, err
+	}, opts)
+}
+
+func (f *Future[T]) OnError(cb func(err error), opts ...TaskOption) *Future[T] {
+	return f.chain(func(data T, err error) (T, error) {
+		if err != nil {
+			cb(err)
+		}
+		return data, err
+	}, opts)
+}
+
+func (f *Future[T]) chain(next func(data T, err error) (T, error), opts []TaskOption) *Future[T] {
+	opts = append([]TaskOption{withSpanLinks(f.task.GetContext())}, opts...)
+
+	return &Future[T]{
+		ctx: f.ctx,
+		task: NewTask(f.ctx, func() (T, error) {
+			return next(f.task.Await())
+		}, opts...),
+	}
+}
+
+func (f *Future[T]) Await() (T, error) {
+	return f.task.Await()
+}
+
+func (f *Future[T]) Subscribe(cb func(data T, err error)) {
+	f.task.Subscribe(cb)
+}
+
+func (f *Future[T]) settle() (T, error) {
+	return awaitSettled(f.task)
+}
+
+func (f *Future[T]) GetContext() context.Context {
+	return f.task.GetContext()
+}
+
+func (f *Future[T]) GetError() error {
+	return f.task.GetError()
+}
+
+func (f *Future[T]) timing() (TaskTiming, bool) {
+	return TimingOf(f.task)
+}
+
+func (f *Future[T]) info() TaskInfo {
+	if provider, ok := f.task.(taskInfoProvider); ok {
+		return provider.info()
+	}
+
+	return TaskInfo{}
+}
@@ -0,0 +1,132 @@
+package async
+
+import (
+	"errors"
+	"github.com/stretchr/testify/require"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+func TestFuture(t *testing.T) {
+	err := errors.New("i am error")
+	increment := func(i int) (int, error) {
+		return i + 1, nil
+	}
+
+	testCases := []struct {
+		name   string
+		future func(calls *atomic.Int32) *Future[int]
+		result int
+		err    error
+		calls  int32
+	}{
+		{
+			name: "then chain",
+			future: func(calls *atomic.Int32) *Future[int] {
+				return NewFuture(nil, func() (int, error) {
+					return 1, nil
+				}).Then(increment).Then(increment)
+			},
+			result: 3,
+		},
+		{
+			name: "then is skipped on error",
+			future: func(calls *atomic.Int32) *Future[int] {
+				return NewFuture(nil, func() (int, error) {
+					return 0, err
+				}).Then(func(i int) (int, error) {
+					calls.Add(1)
+					return i, nil
+				})
+			},
+			err: err,
+		},
+		{
+			name: "catch recovers",
+			future: func(calls *atomic.Int32) *Future[int] {
+				return NewFuture(nil, func() (int, error) {
+					return 0, err
+				}).Then(increment).Catch(func(caught error) (int, error) {
+					if errors.Is(caught, err) {
+						calls.Add(1)
+					}
+					return 10, nil
+				}).Then(increment)
+			},
+			result: 11,
+			calls:  1,
+		},
+		{
+			name: "catch is skipped on success",
+			future: func(calls *atomic.Int32) *Future[int] {
+				return NewFuture(nil, func() (int, error) {
+					return 1, nil
+				}).Catch(func(error) (int, error) {
+					calls.Add(1)
+					return 10, nil
+				})
+			},
+			result: 1,
+		},
+		{
+			name: "callbacks on success",
+			future: func(calls *atomic.Int32) *Future[int] {
+				return FutureOf(nil, Resolved(nil, 1)).OnSuccess(func(int) {
+					calls.Add(1)
+				}).OnError(func(error) {
+					calls.Add(10)
+				}).Finally(func() {
+					calls.Add(100)
+				})
+			},
+			result: 1,
+			calls:  101,
+		},
+		{
+			name: "callbacks on error",
+			future: func(calls *atomic.Int32) *Future[int] {
+				return FutureOf(nil, NewErrTask[int](nil, err)).OnSuccess(func(int) {
+					calls.Add(1)
+				}).OnError(func(error) {
+					calls.Add(10)
+				}).Finally(func() {
+					calls.Add(100)
+				})
+			},
+			err:   err,
+			calls: 110,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls atomic.Int32
+			future := tc.future(&calls)
+
+			result, err := future.Await()
+
+			require.Equal(t, tc.result, result)
+			require.ErrorIs(t, err, tc.err)
+			require.Equal(t, tc.calls, calls.Load())
+		})
+	}
+}
+
+func TestFuture_FMap(t *testing.T) {
+	future := NewFuture(nil, func() (int, error) {
+		return 1, nil
+	}).Then(func(i int) (int, error) {
+		return i * 2, nil
+	})
+
+	promised := FMap(nil, future, func(i int) (string, error) {
+		return strconv.Itoa(i), nil
+	})
+	result, err := FutureOf(nil, promised).Then(func(s string) (string, error) {
+		return s + "!", nil
+	}).Await()
+
+	require.NoError(t, err)
+	require.Equal(t, "2!", result)
+}
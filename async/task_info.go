@@ -0,0 +1,75 @@
+package async
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+var lastTaskID atomic.Uint64
+
+type TaskInfo struct {
+	ID   uint64
+	Name string
+	// ParentID is the ID of the task whose context the task was created with
+	ParentID uint64
+}
+
+type taskInfoCtxKey struct{}
+
+func newTaskInfo(ctx context.Context, cfg *taskConfig) TaskInfo {
+	info := TaskInfo{
+		ID:   lastTaskID.Add(1),
+		Name: cfg.name,
+	}
+	if parent, ok := TaskInfoFromContext(ctx); ok {
+		info.ParentID = parent.ID
+	}
+
+	return info
+}
+
+// TaskInfoFromContext returns the info of the task ctx belongs to
+func TaskInfoFromContext(ctx context.Context) (TaskInfo, bool) {
+	if ctx == nil {
+		return TaskInfo{}, false
+	}
+	info, ok := ctx.Value(taskInfoCtxKey{}).(TaskInfo)
+
+	return info, ok
+}
+
+func contextWithTaskInfo(ctx context.Context, info TaskInfo) context.Context {
+	return context.WithValue(ctx, taskInfoCtxKey{}, info)
+}
+
+// taskInfoProvider is implemented by tasks created by this package
+type taskInfoProvider interface {
+	info() TaskInfo
+}
+
+// TaskTiming is the timing of a resolved task
+type TaskTiming struct {
+	Start time.Time
+	End   time.Time
+	// Waited is the part of the run spent before the task function started, see WithRateLimiter and WithBulkhead
+	Waited time.Duration
+}
+
+func (t TaskTiming) Duration() time.Duration {
+	return t.End.Sub(t.Start)
+}
+
+// TimingOf returns the timing of task, ok is false while it is pending and for tasks not created by NewTask
+func TimingOf(task taskAny) (timing TaskTiming, ok bool) {
+	if provider, isProvider := task.(timingProvider); isProvider {
+		return provider.timing()
+	}
+
+	return TaskTiming{}, false
+}
+
+// timingProvider is implemented by tasks created by this package
+type timingProvider interface {
+	timing() (TaskTiming, bool)
+}
@@ -0,0 +1,90 @@
+package async
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Interceptor is invoked around the execution of a task.
+// next runs the rest of the chain and eventually the task function
+type Interceptor func(ctx context.Context, info TaskInfo, next func() (any, error)) (any, error)
+
+var (
+	interceptorsMtx        sync.RWMutex
+	registeredInterceptors []Interceptor
+)
+
+// Use registers interceptors for all tasks created afterward
+func Use(interceptors ...Interceptor) {
+	interceptorsMtx.Lock()
+	defer interceptorsMtx.Unlock()
+
+	registeredInterceptors = append(registeredInterceptors, interceptors...)
+}
+
+func ResetInterceptors() {
+	interceptorsMtx.Lock()
+	defer interceptorsMtx.Unlock()
+
+	registeredInterceptors = nil
+}
+
+func WithInterceptors(interceptors ...Interceptor) TaskOption {
+	return func(cfg *taskConfig) {
+		cfg.interceptors = append(cfg.interceptors, interceptors...)
+	}
+}
+
+func globalInterceptors() []Interceptor {
+	interceptorsMtx.RLock()
+	defer interceptorsMtx.RUnlock()
+
+	if len(registeredInterceptors) == 0 {
+		return nil
+	}
+
+	return append([]Interceptor{}, registeredInterceptors...)
+}
+
+func chainInterceptors(ctx context.Context, info TaskInfo, chain []Interceptor, next func() (any, error)) func() (any, error) {
+	for i := len(chain) - 1; i >= 0; i-- {
+		interceptor, inner := chain[i], next
+		if interceptor == nil {
+			continue
+		}
+		next = func() (any, error) {
+			return interceptor(ctx, info, inner)
+		}
+	}
+
+	return next
+}
+
+// Hooks are notified of task execution events
+type Hooks struct {
+	Before func(ctx context.Context, info TaskInfo)
+	After  func(ctx context.Context, info TaskInfo, data any, err error, elapsed time.Duration)
+	Panic  func(ctx context.Context, info TaskInfo, err *PanicError)
+}
+
+func (h Hooks) Interceptor() Interceptor {
+	return func(ctx context.Context, info TaskInfo, next func() (any, error)) (any, error) {
+		if h.Before != nil {
+			h.Before(ctx, info)
+		}
+
+		clock := clockFromContext(ctx)
+		start := clock.Now()
+		data, err := next()
+
+		if panicErr, ok := err.(*PanicError); ok && h.Panic != nil {
+			h.Panic(ctx, info, panicErr)
+		}
+		if h.After != nil {
+			h.After(ctx, info, data, err, clock.Now().Sub(start))
+		}
+
+		return data, err
+	}
+}
@@ -0,0 +1,206 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+	"time"
+)
+
+func recordingInterceptor(mtx *sync.Mutex, calls *[]string, name string) Interceptor {
+	return func(ctx context.Context, info TaskInfo, next func() (any, error)) (any, error) {
+		mtx.Lock()
+		*calls = append(*calls, name+":before:"+info.Name)
+		mtx.Unlock()
+
+		data, err := next()
+
+		mtx.Lock()
+		*calls = append(*calls, name+":after:"+info.Name)
+		mtx.Unlock()
+
+		return data, err
+	}
+}
+
+func TestTask_Interceptors(t *testing.T) {
+	defer ResetInterceptors()
+
+	t.Run("global interceptors wrap per task interceptors", func(t *testing.T) {
+		defer ResetInterceptors()
+		var (
+			mtx   sync.Mutex
+			calls []string
+		)
+		Use(recordingInterceptor(&mtx, &calls, "global"))
+		task := NewTask(nil, func() (int, error) {
+			mtx.Lock()
+			calls = append(calls, "task")
+			mtx.Unlock()
+			return 1, nil
+		}, WithName("loader"), WithInterceptors(recordingInterceptor(&mtx, &calls, "local")))
+
+		result, err := task.Await()
+
+		require.NoError(t, err)
+		require.Equal(t, 1, result)
+		require.Equal(t, []string{
+			"global:before:loader",
+			"local:before:loader",
+			"task",
+			"local:after:loader",
+			"global:after:loader",
+		}, calls)
+	})
+
+	t.Run("interceptor replaces result", func(t *testing.T) {
+		err := errors.New("i am error")
+		task := NewTask(nil, func() (int, error) {
+			return 0, err
+		}, WithInterceptors(func(ctx context.Context, info TaskInfo, next func() (any, error)) (any, error) {
+			if _, err := next(); err != nil {
+				return 2, nil
+			}
+			return nil, errors.New("unexpected")
+		}))
+
+		result, awaitErr := task.Await()
+
+		require.NoError(t, awaitErr)
+		require.Equal(t, 2, result)
+	})
+
+	t.Run("interceptor result of another type", func(t *testing.T) {
+		task := NewTask(nil, func() (int, error) {
+			return 1, nil
+		}, WithInterceptors(func(ctx context.Context, info TaskInfo, next func() (any, error)) (any, error) {
+			data, err := next()
+			return fmt.Sprint(data), err
+		}))
+
+		_, err := task.Await()
+
+		require.ErrorIs(t, err, ErrInterceptorResult)
+	})
+
+	t.Run("mapper is intercepted", func(t *testing.T) {
+		var (
+			mtx   sync.Mutex
+			calls []string
+		)
+		task := NewTask(nil, func() (int, error) {
+			return 1, nil
+		})
+		promised := FMap(nil, task, func(i int) (int, error) {
+			return i + 1, nil
+		}, WithName("mapper"), WithInterceptors(recordingInterceptor(&mtx, &calls, "local")))
+
+		result, err := promised.Await()
+
+		require.NoError(t, err)
+		require.Equal(t, 2, result)
+		require.Equal(t, []string{"local:before:mapper", "local:after:mapper"}, calls)
+	})
+
+	t.Run("interceptor panic is converted", func(t *testing.T) {
+		task := NewTask(nil, func() (int, error) {
+			return 1, nil
+		}, WithInterceptors(func(ctx context.Context, info TaskInfo, next func() (any, error)) (any, error) {
+			panic("boom")
+		}))
+
+		_, err := task.Await()
+
+		var panicErr *PanicError
+		require.ErrorAs(t, err, &panicErr)
+	})
+}
+
+func TestHooks(t *testing.T) {
+	err := errors.New("i am error")
+	testCases := []struct {
+		name     string
+		f        func() (int, error)
+		panicked bool
+		err      error
+	}{
+		{
+			name: "success",
+			f: func() (int, error) {
+				time.Sleep(5 * time.Millisecond)
+				return 1, nil
+			},
+		},
+		{
+			name: "error",
+			f: func() (int, error) {
+				return 0, err
+			},
+			err: err,
+		},
+		{
+			name:     "panic",
+			f:        panickingFunction("boom"),
+			panicked: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				before   bool
+				panicked bool
+				afterErr error
+			)
+			hooks := Hooks{
+				Before: func(ctx context.Context, info TaskInfo) {
+					before = true
+				},
+				After: func(ctx context.Context, info TaskInfo, data any, err error, elapsed time.Duration) {
+					afterErr = err
+				},
+				Panic: func(ctx context.Context, info TaskInfo, err *PanicError) {
+					panicked = true
+				},
+			}
+			task := NewTask(nil, tc.f, WithInterceptors(hooks.Interceptor()))
+
+			_, awaitErr := task.Await()
+
+			require.True(t, before)
+			require.Equal(t, tc.panicked, panicked)
+			if tc.panicked {
+				var panicErr *PanicError
+				require.ErrorAs(t, afterErr, &panicErr)
+				require.ErrorAs(t, awaitErr, &panicErr)
+				return
+			}
+			require.ErrorIs(t, afterErr, tc.err)
+			require.ErrorIs(t, awaitErr, tc.err)
+		})
+	}
+}
+
+func TestHooks_Clock(t *testing.T) {
+	err := errors.New("i am error")
+	clock := &steppedClock{Clock: SystemClock(), now: time.Now()}
+	var elapsed time.Duration
+	hooks := Hooks{
+		After: func(ctx context.Context, info TaskInfo, data any, err error, d time.Duration) {
+			elapsed = d
+		},
+	}
+
+	_, awaitErr := NewTask(nil, func() (int, error) {
+		clock.advance(time.Minute)
+		return 0, err
+	}, WithClock(clock), WithInterceptors(hooks.Interceptor())).Await()
+
+	var taskErr *TaskError
+	require.ErrorAs(t, awaitErr, &taskErr)
+	require.Equal(t, time.Minute, elapsed)
+	require.Equal(t, taskErr.Duration(), elapsed)
+}
@@ -0,0 +1,93 @@
+package async
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+type logAttrsCtxKey struct{}
+
+var defaultLogger atomic.Pointer[slog.Logger]
+
+// SetLogger sets the logger of task events, nil disables logging
+func SetLogger(logger *slog.Logger) {
+	defaultLogger.Store(logger)
+}
+
+func WithLogger(logger *slog.Logger) TaskOption {
+	return func(cfg *taskConfig) {
+		cfg.logger = logger
+	}
+}
+
+// WithLogAttrs adds attrs to the records logged by tasks created with the returned context
+func WithLogAttrs(ctx context.Context, attrs ...slog.Attr) context.Context {
+	if ctx == nil {
+		ctx = context.TODO()
+	}
+	prev := logAttrsFromContext(ctx)
+	merged := make([]slog.Attr, 0, len(prev)+len(attrs))
+	merged = append(append(merged, prev...), attrs...)
+
+	return context.WithValue(ctx, logAttrsCtxKey{}, merged)
+}
+
+func logAttrsFromContext(ctx context.Context) []slog.Attr {
+	attrs, _ := ctx.Value(logAttrsCtxKey{}).([]slog.Attr)
+
+	return attrs
+}
+
+func logTaskStart(ctx context.Context, logger *slog.Logger, info TaskInfo) {
+	if logger == nil || !logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+
+	logger.LogAttrs(ctx, slog.LevelDebug, "task started", taskLogAttrs(ctx, info)...)
+}
+
+func logTaskFinish(ctx context.Context, logger *slog.Logger, info TaskInfo, outcome Outcome, err error, elapsed, waited time.Duration) {
+	if logger == nil {
+		return
+	}
+
+	level, msg := slog.LevelDebug, "task completed"
+	switch outcome {
+	case OutcomeFailed:
+		level, msg = slog.LevelError, "task failed"
+	case OutcomePanicked:
+		level, msg = slog.LevelError, "task panicked"
+	case OutcomeTimedOut:
+		level, msg = slog.LevelWarn, "task timed out"
+	case OutcomeCancelled:
+		level, msg = slog.LevelWarn, "task cancelled"
+	}
+	if !logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := append(taskLogAttrs(ctx, info), slog.Duration("duration", elapsed))
+	if waited > 0 {
+		attrs = append(attrs, slog.Duration("waited", waited))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.Any("error", err))
+	}
+	if panicErr, ok := err.(*PanicError); ok {
+		attrs = append(attrs, slog.String("stack", string(panicErr.Stack)))
+	}
+	logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+func taskLogAttrs(ctx context.Context, info TaskInfo) []slog.Attr {
+	ctxAttrs := logAttrsFromContext(ctx)
+	attrs := make([]slog.Attr, 0, len(ctxAttrs)+4)
+	attrs = append(attrs, slog.Uint64("task_id", info.ID))
+	if info.Name != "" {
+		attrs = append(attrs, slog.String("task", info.Name))
+	}
+
+	return append(attrs, ctxAttrs...)
+}
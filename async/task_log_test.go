@@ -0,0 +1,111 @@
+package async
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func logRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		record := map[string]any{}
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+
+	return records
+}
+
+func TestTask_Logging(t *testing.T) {
+	err := errors.New("i am error")
+	cancelledCtx, cancel := context.WithCancel(context.TODO())
+	cancel()
+
+	testCases := []struct {
+		name  string
+		ctx   context.Context
+		f     func() (int, error)
+		level string
+		msg   string
+		err   bool
+	}{
+		{
+			name: "completed",
+			f: func() (int, error) {
+				return 1, nil
+			},
+			level: "DEBUG",
+			msg:   "task completed",
+		},
+		{
+			name: "failed",
+			f: func() (int, error) {
+				return 0, err
+			},
+			level: "ERROR",
+			msg:   "task failed",
+			err:   true,
+		},
+		{
+			name:  "panicked",
+			f:     panickingFunction("boom"),
+			level: "ERROR",
+			msg:   "task panicked",
+			err:   true,
+		},
+		{
+			name: "cancelled",
+			ctx:  cancelledCtx,
+			f: func() (int, error) {
+				time.Sleep(time.Second)
+				return 0, nil
+			},
+			level: "WARN",
+			msg:   "task cancelled",
+			err:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+			ctx := tc.ctx
+			if ctx == nil {
+				ctx = context.TODO()
+			}
+			ctx = WithLogAttrs(ctx, slog.String("request_id", "42"))
+
+			task := NewTask(ctx, tc.f, WithName(tc.name), WithLogger(logger))
+			_ = task.GetError()
+
+			records := logRecords(t, &buf)
+			require.Len(t, records, 2)
+			require.Equal(t, "task started", records[0]["msg"])
+			require.Equal(t, tc.name, records[0]["task"])
+			require.Equal(t, "42", records[0]["request_id"])
+
+			finished := records[1]
+			require.Equal(t, tc.msg, finished["msg"])
+			require.Equal(t, tc.level, finished["level"])
+			require.Equal(t, tc.name, finished["task"])
+			require.Equal(t, "42", finished["request_id"])
+			require.Contains(t, finished, "duration")
+			if tc.err {
+				require.Contains(t, finished, "error")
+			} else {
+				require.NotContains(t, finished, "error")
+			}
+		})
+	}
+}
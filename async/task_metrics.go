@@ -0,0 +1,182 @@
+package async
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type Outcome string
+
+const (
+	OutcomeSucceeded Outcome = "succeeded"
+	OutcomeFailed    Outcome = "failed"
+	OutcomeCancelled Outcome = "cancelled"
+	OutcomeTimedOut  Outcome = "timed_out"
+	OutcomePanicked  Outcome = "panicked"
+)
+
+var outcomes = []Outcome{OutcomeSucceeded, OutcomeFailed, OutcomeCancelled, OutcomeTimedOut, OutcomePanicked}
+
+// outcomeOf classifies the error a task function resolved with
+func outcomeOf(err error) Outcome {
+	if err == nil {
+		return OutcomeSucceeded
+	}
+	if _, ok := err.(*PanicError); ok {
+		return OutcomePanicked
+	}
+	if errors.Is(err, ErrTaskTimeout) {
+		return OutcomeTimedOut
+	}
+	if errors.Is(err, ErrTaskContextCancelled) {
+		return OutcomeCancelled
+	}
+
+	return OutcomeFailed
+}
+
+type MetricsSink interface {
+	TaskStarted(name string)
+	TaskFinished(name string, outcome Outcome, elapsed time.Duration)
+}
+
+var defaultMetricsSink atomic.Pointer[MetricsSink]
+
+func SetMetricsSink(sink MetricsSink) {
+	if sink == nil {
+		defaultMetricsSink.Store(nil)
+		return
+	}
+	defaultMetricsSink.Store(&sink)
+}
+
+func WithMetricsSink(sink MetricsSink) TaskOption {
+	return func(cfg *taskConfig) {
+		cfg.metrics = sink
+	}
+}
+
+func globalMetricsSink() MetricsSink {
+	if sink := defaultMetricsSink.Load(); sink != nil {
+		return *sink
+	}
+
+	return nil
+}
+
+// DefaultDurationBuckets are upper bounds in seconds of the latency histogram buckets
+var DefaultDurationBuckets = []float64{.001, .005, .01, .05, .1, .5, 1, 5, 10, 60}
+
+// Metrics is an in-process MetricsSink
+type Metrics struct {
+	buckets []float64
+	mtx     sync.RWMutex
+	tasks   map[string]*taskMetrics
+}
+
+type taskMetrics struct {
+	started  uint64
+	inFlight int64
+	finished map[Outcome]uint64
+	counts   []uint64
+	sum      float64
+	count    uint64
+}
+
+// TaskMetrics is a snapshot of the metrics of tasks with the same name
+type TaskMetrics struct {
+	Name     string
+	Started  uint64
+	InFlight int64
+	Finished map[Outcome]uint64
+	// BucketCounts are cumulative counts of durations within Buckets
+	Buckets      []float64
+	BucketCounts []uint64
+	DurationSum  time.Duration
+	Count        uint64
+}
+
+func NewMetrics(buckets ...float64) *Metrics {
+	if len(buckets) == 0 {
+		buckets = DefaultDurationBuckets
+	}
+	buckets = append([]float64{}, buckets...)
+	sort.Float64s(buckets)
+
+	return &Metrics{
+		buckets: buckets,
+		tasks:   make(map[string]*taskMetrics),
+	}
+}
+
+func (m *Metrics) TaskStarted(name string) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	tm := m.get(name)
+	tm.started++
+	tm.inFlight++
+}
+
+func (m *Metrics) TaskFinished(name string, outcome Outcome, elapsed time.Duration) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	tm := m.get(name)
+	tm.inFlight--
+	tm.finished[outcome]++
+
+	seconds := elapsed.Seconds()
+	for i, bound := range m.buckets {
+		if seconds <= bound {
+			tm.counts[i]++
+		}
+	}
+	tm.sum += seconds
+	tm.count++
+}
+
+// Snapshot returns the metrics of every task name sorted by name
+func (m *Metrics) Snapshot() []TaskMetrics {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	result := make([]TaskMetrics, 0, len(m.tasks))
+	for name, tm := range m.tasks {
+		finished := make(map[Outcome]uint64, len(tm.finished))
+		for outcome, count := range tm.finished {
+			finished[outcome] = count
+		}
+		result = append(result, TaskMetrics{
+			Name:         name,
+			Started:      tm.started,
+			InFlight:     tm.inFlight,
+			Finished:     finished,
+			Buckets:      m.buckets,
+			BucketCounts: append([]uint64{}, tm.counts...),
+			DurationSum:  time.Duration(tm.sum * float64(time.Second)),
+			Count:        tm.count,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
+
+func (m *Metrics) get(name string) *taskMetrics {
+	tm, ok := m.tasks[name]
+	if !ok {
+		tm = &taskMetrics{
+			finished: make(map[Outcome]uint64),
+			counts:   make([]uint64, len(m.buckets)),
+		}
+		m.tasks[name] = tm
+	}
+
+	return tm
+}
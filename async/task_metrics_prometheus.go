@@ -0,0 +1,64 @@
+package async
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// PrometheusHandler exposes m in the Prometheus text format
+func PrometheusHandler(m *Metrics) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_ = m.WritePrometheus(w)
+	})
+}
+
+func (m *Metrics) WritePrometheus(w io.Writer) error {
+	snapshot := m.Snapshot()
+	var sb strings.Builder
+
+	sb.WriteString("# HELP aysync_tasks_started_total Number of started tasks.\n")
+	sb.WriteString("# TYPE aysync_tasks_started_total counter\n")
+	for _, tm := range snapshot {
+		fmt.Fprintf(&sb, "aysync_tasks_started_total{task=%s} %d\n", promQuote(tm.Name), tm.Started)
+	}
+
+	sb.WriteString("# HELP aysync_tasks_finished_total Number of finished tasks by outcome.\n")
+	sb.WriteString("# TYPE aysync_tasks_finished_total counter\n")
+	for _, tm := range snapshot {
+		for _, outcome := range outcomes {
+			fmt.Fprintf(&sb, "aysync_tasks_finished_total{task=%s,outcome=%q} %d\n", promQuote(tm.Name), outcome, tm.Finished[outcome])
+		}
+	}
+
+	sb.WriteString("# HELP aysync_tasks_in_flight Number of running tasks.\n")
+	sb.WriteString("# TYPE aysync_tasks_in_flight gauge\n")
+	for _, tm := range snapshot {
+		fmt.Fprintf(&sb, "aysync_tasks_in_flight{task=%s} %d\n", promQuote(tm.Name), tm.InFlight)
+	}
+
+	sb.WriteString("# HELP aysync_task_duration_seconds Task latency.\n")
+	sb.WriteString("# TYPE aysync_task_duration_seconds histogram\n")
+	for _, tm := range snapshot {
+		task := promQuote(tm.Name)
+		for i, bound := range tm.Buckets {
+			fmt.Fprintf(&sb, "aysync_task_duration_seconds_bucket{task=%s,le=\"%s\"} %d\n", task, strconv.FormatFloat(bound, 'g', -1, 64), tm.BucketCounts[i])
+		}
+		fmt.Fprintf(&sb, "aysync_task_duration_seconds_bucket{task=%s,le=\"+Inf\"} %d\n", task, tm.Count)
+		fmt.Fprintf(&sb, "aysync_task_duration_seconds_sum{task=%s} %s\n", task, strconv.FormatFloat(tm.DurationSum.Seconds(), 'g', -1, 64))
+		fmt.Fprintf(&sb, "aysync_task_duration_seconds_count{task=%s} %d\n", task, tm.Count)
+	}
+
+	_, err := io.WriteString(w, sb.String())
+
+	return err
+}
+
+var promLabelReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func promQuote(value string) string {
+	return `"` + promLabelReplacer.Replace(value) + `"`
+}
@@ -0,0 +1,100 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMetrics(t *testing.T) {
+	err := errors.New("i am error")
+	cancelledCtx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	metrics := NewMetrics()
+
+	started, release := make(chan struct{}), make(chan struct{})
+	var releaseOnce sync.Once
+	releaseRunning := func() {
+		releaseOnce.Do(func() {
+			close(release)
+		})
+	}
+	defer releaseRunning()
+	running := NewTask(nil, func() (int, error) {
+		close(started)
+		<-release
+		return 1, nil
+	}, WithName("running"), WithMetricsSink(metrics))
+	<-started
+
+	tasks := []taskAny{
+		NewTask(nil, func() (int, error) {
+			return 1, nil
+		}, WithName("loader"), WithMetricsSink(metrics)),
+		NewTask(nil, func() (int, error) {
+			return 0, err
+		}, WithName("loader"), WithMetricsSink(metrics)),
+		NewTask(nil, panickingFunction("boom"), WithName("loader"), WithMetricsSink(metrics)),
+		NewTask(cancelledCtx, func() (int, error) {
+			time.Sleep(time.Second)
+			return 0, nil
+		}, WithName("loader"), WithMetricsSink(metrics)),
+	}
+	for _, task := range tasks {
+		_ = task.GetError()
+	}
+
+	snapshot := metrics.Snapshot()
+	require.Len(t, snapshot, 2)
+	loader, runningMetrics := snapshot[0], snapshot[1]
+
+	require.Equal(t, "loader", loader.Name)
+	require.Equal(t, uint64(4), loader.Started)
+	require.Equal(t, int64(0), loader.InFlight)
+	require.Equal(t, map[Outcome]uint64{
+		OutcomeSucceeded: 1,
+		OutcomeFailed:    1,
+		OutcomePanicked:  1,
+		OutcomeCancelled: 1,
+	}, loader.Finished)
+	require.Equal(t, uint64(4), loader.Count)
+
+	require.Equal(t, "running", runningMetrics.Name)
+	require.Equal(t, int64(1), runningMetrics.InFlight)
+
+	releaseRunning()
+	require.NoError(t, running.GetError())
+	require.Equal(t, int64(0), metrics.Snapshot()[1].InFlight)
+}
+
+func TestPrometheusHandler(t *testing.T) {
+	metrics := NewMetrics(0.5, 1)
+	metrics.TaskStarted(`quoted "name"`)
+	metrics.TaskFinished(`quoted "name"`, OutcomeSucceeded, 700*time.Millisecond)
+
+	recorder := httptest.NewRecorder()
+	PrometheusHandler(metrics).ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	body, err := io.ReadAll(recorder.Result().Body)
+	require.NoError(t, err)
+
+	require.True(t, strings.HasPrefix(recorder.Header().Get("Content-Type"), "text/plain"))
+	for _, line := range []string{
+		`aysync_tasks_started_total{task="quoted \"name\""} 1`,
+		`aysync_tasks_finished_total{task="quoted \"name\"",outcome="succeeded"} 1`,
+		`aysync_tasks_finished_total{task="quoted \"name\"",outcome="panicked"} 0`,
+		`aysync_tasks_in_flight{task="quoted \"name\""} 0`,
+		`aysync_task_duration_seconds_bucket{task="quoted \"name\"",le="0.5"} 0`,
+		`aysync_task_duration_seconds_bucket{task="quoted \"name\"",le="1"} 1`,
+		`aysync_task_duration_seconds_bucket{task="quoted \"name\"",le="+Inf"} 1`,
+		`aysync_task_duration_seconds_sum{task="quoted \"name\""} 0.7`,
+		`aysync_task_duration_seconds_count{task="quoted \"name\""} 1`,
+	} {
+		require.Contains(t, string(body), line+"\n")
+	}
+}
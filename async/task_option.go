@@ -0,0 +1,91 @@
+package async
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+type taskConfig struct {
+	name         string
+	panicPolicy  PanicPolicy
+	interceptors []Interceptor
+	tracer       Tracer
+	spanLinks    []Span
+	metrics      MetricsSink
+	logger       *slog.Logger
+	registry     *Registry
+	clock        Clock
+	timeout      time.Duration
+	attempts     *atomic.Int32
+	gates        []gate
+}
+
+type TaskOption func(cfg *taskConfig)
+
+// gate holds back the start of a task function until it returns, release is called once the function returns
+type gate func(ctx context.Context) (release func(), err error)
+
+func WithName(name string) TaskOption {
+	return func(cfg *taskConfig) {
+		cfg.name = name
+	}
+}
+
+func newTaskConfig(opts []TaskOption) *taskConfig {
+	cfg := &taskConfig{
+		panicPolicy:  PanicPolicy(defaultPanicPolicy.Load()),
+		interceptors: globalInterceptors(),
+		tracer:       globalTracer(),
+		metrics:      globalMetricsSink(),
+		logger:       defaultLogger.Load(),
+		registry:     defaultRegistry.Load(),
+		clock:        globalClock(),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(cfg)
+		}
+	}
+
+	return cfg
+}
+
+// withAttempts reports the value of attempts as TaskError.Attempts
+func withAttempts(attempts *atomic.Int32) TaskOption {
+	return func(cfg *taskConfig) {
+		cfg.attempts = attempts
+	}
+}
+
+// enter passes through the gates of the task, releasing the ones passed when any of them fails
+func (cfg *taskConfig) enter(ctx context.Context) (release func(), err error) {
+	releases := make([]func(), 0, len(cfg.gates))
+	release = func() {
+		for i := len(releases) - 1; i >= 0; i-- {
+			releases[i]()
+		}
+	}
+
+	for _, g := range cfg.gates {
+		r, err := g(ctx)
+		if err != nil {
+			release()
+			return nil, err
+		}
+		releases = append(releases, r)
+	}
+
+	return release, nil
+}
+
+// watchdog starts the timer bounding the run of the task. Tasks without WithTimeout are bounded by
+// defaultTimeout of system time, so only the timeouts set explicitly are timers of the task clock
+func (cfg *taskConfig) watchdog() Timer {
+	if cfg.timeout > 0 {
+		return cfg.clock.NewTimer(cfg.timeout)
+	}
+
+	return SystemClock().NewTimer(defaultTimeout)
+}
@@ -0,0 +1,108 @@
+package async
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync/atomic"
+)
+
+// PanicError is returned by tasks whose function panicked
+type PanicError struct {
+	Value    any
+	Stack    []byte
+	TaskName string
+}
+
+func newPanicError(value any, taskName string) *PanicError {
+	return &PanicError{
+		Value:    value,
+		Stack:    debug.Stack(),
+		TaskName: taskName,
+	}
+}
+
+func (e *PanicError) Error() string {
+	if e.TaskName != "" {
+		return fmt.Sprintf("task %q panic'd: %v", e.TaskName, e.Value)
+	}
+
+	return fmt.Sprintf("task panic'd: %v", e.Value)
+}
+
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+
+	return err
+}
+
+type PanicPolicy int32
+
+const (
+	// PanicConvert resolves the task with *PanicError
+	PanicConvert PanicPolicy = iota
+	// PanicRepanic re-panics with *PanicError on the goroutine awaiting the task,
+	// Subscribe callbacks get *PanicError as their error since nothing awaits the task
+	PanicRepanic
+	// PanicHandle passes *PanicError to the handler set by SetPanicHandler
+	// and resolves the task with it
+	PanicHandle
+)
+
+var (
+	defaultPanicPolicy atomic.Int32
+	panicHandler       atomic.Pointer[func(err *PanicError)]
+)
+
+func SetPanicPolicy(policy PanicPolicy) {
+	defaultPanicPolicy.Store(int32(policy))
+}
+
+func SetPanicHandler(handler func(err *PanicError)) {
+	if handler == nil {
+		panicHandler.Store(nil)
+		return
+	}
+	panicHandler.Store(&handler)
+}
+
+func WithPanicPolicy(policy PanicPolicy) TaskOption {
+	return func(cfg *taskConfig) {
+		cfg.panicPolicy = policy
+	}
+}
+
+func handlePanic(policy PanicPolicy, err *PanicError) {
+	if policy != PanicHandle {
+		return
+	}
+	if handler := panicHandler.Load(); handler != nil {
+		(*handler)(err)
+	}
+}
+
+func repanicOnError(policy PanicPolicy, err error) {
+	if policy != PanicRepanic {
+		return
+	}
+
+	var panicErr *PanicError
+	if errors.As(err, &panicErr) {
+		panic(panicErr)
+	}
+}
+
+// settler is implemented by tasks that can be awaited without re-panicking under PanicRepanic
+type settler[T any] interface {
+	settle() (T, error)
+}
+
+// awaitSettled awaits tsk on goroutines of the library, passing a panic of tsk on as *PanicError
+// instead of re-panicking where no caller could recover it
+func awaitSettled[T any](tsk Task[T]) (T, error) {
+	if s, ok := tsk.(settler[T]); ok {
+		return s.settle()
+	}
+
+	return tsk.Await()
+}
@@ -0,0 +1,154 @@
+package async
+
+import (
+	"errors"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func panickingFunction(value any) func() (int, error) {
+	return func() (int, error) {
+		panic(value)
+	}
+}
+
+func TestTask_Panic(t *testing.T) {
+	err := errors.New("i am error")
+
+	testCases := []struct {
+		name     string
+		opts     []TaskOption
+		value    any
+		message  string
+		taskName string
+		wrapped  error
+	}{
+		{
+			name:    "string panic",
+			value:   "boom",
+			message: "task panic'd: boom",
+		},
+		{
+			name:    "error panic",
+			value:   err,
+			message: "task panic'd: i am error",
+			wrapped: err,
+		},
+		{
+			name:    "non-error panic keeps prefix",
+			value:   42,
+			message: "task panic'd: 42",
+		},
+		{
+			name:     "named task",
+			opts:     []TaskOption{WithName("loader")},
+			value:    "boom",
+			message:  `task "loader" panic'd: boom`,
+			taskName: "loader",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			task := NewTask(nil, panickingFunction(tc.value), tc.opts...)
+			_, err := task.Await()
+
+			var panicErr *PanicError
+			require.ErrorAs(t, err, &panicErr)
+			require.Equal(t, tc.value, panicErr.Value)
+			require.Equal(t, tc.taskName, panicErr.TaskName)
+			require.Equal(t, tc.message, panicErr.Error())
+			if !strings.Contains(string(panicErr.Stack), "panickingFunction") {
+				t.Errorf("stack does not contain panic site:\n%s", panicErr.Stack)
+			}
+			if tc.wrapped != nil {
+				require.ErrorIs(t, err, tc.wrapped)
+			}
+		})
+	}
+}
+
+func TestTask_PanicPolicy(t *testing.T) {
+	defer SetPanicPolicy(PanicConvert)
+	defer SetPanicHandler(nil)
+
+	t.Run("repanic on await", func(t *testing.T) {
+		task := NewTask(nil, panickingFunction("boom"), WithPanicPolicy(PanicRepanic))
+
+		defer func() {
+			excp := recover()
+			panicErr, ok := excp.(*PanicError)
+			require.True(t, ok)
+			require.Equal(t, "boom", panicErr.Value)
+		}()
+		_, _ = task.Await()
+		t.Error("await did not panic")
+	})
+
+	t.Run("repanic on validation", func(t *testing.T) {
+		task := NewTask(nil, panickingFunction("boom"), WithPanicPolicy(PanicRepanic))
+
+		require.Panics(t, func() {
+			_ = task.GetError()
+		})
+	})
+
+	t.Run("repanic on the goroutine calling AreValid", func(t *testing.T) {
+		task := NewTask(nil, panickingFunction("boom"), WithPanicPolicy(PanicRepanic))
+
+		defer func() {
+			excp := recover()
+			panicErr, ok := excp.(*PanicError)
+			require.True(t, ok)
+			require.Equal(t, "boom", panicErr.Value)
+		}()
+		_ = AreValid(nil, task)
+		t.Error("AreValid did not panic")
+	})
+
+	t.Run("subscribe gets the panic as error", func(t *testing.T) {
+		task := NewTask(nil, panickingFunction("boom"), WithPanicPolicy(PanicRepanic))
+		promised := FMap(nil, task, func(data int) (int, error) {
+			return data, nil
+		}, WithPanicPolicy(PanicRepanic))
+
+		for _, subscribed := range []Task[int]{task, FutureOf(nil, task), promised} {
+			errs := make(chan error, 1)
+			subscribed.Subscribe(func(_ int, err error) {
+				errs <- err
+			})
+
+			var panicErr *PanicError
+			require.ErrorAs(t, <-errs, &panicErr)
+		}
+	})
+
+	t.Run("handler is invoked", func(t *testing.T) {
+		handled := make(chan *PanicError, 1)
+		SetPanicHandler(func(err *PanicError) {
+			handled <- err
+		})
+		task := NewTask(nil, panickingFunction("boom"), WithPanicPolicy(PanicHandle))
+
+		_, err := task.Await()
+
+		var panicErr *PanicError
+		require.ErrorAs(t, err, &panicErr)
+		require.Same(t, panicErr, <-handled)
+	})
+
+	t.Run("package policy is the default", func(t *testing.T) {
+		SetPanicPolicy(PanicRepanic)
+		repanicking := NewTask(nil, panickingFunction("boom"))
+		converting := NewTask(nil, panickingFunction("boom"), WithPanicPolicy(PanicConvert))
+		SetPanicPolicy(PanicConvert)
+
+		require.Panics(t, func() {
+			_, _ = repanicking.Await()
+		})
+		require.NotPanics(t, func() {
+			_, _ = converting.Await()
+		})
+	})
+}
@@ -0,0 +1,32 @@
+package async
+
+import (
+	"context"
+	"runtime/pprof"
+	"strconv"
+)
+
+// profilerLabels returns ctx with the runtime/pprof labels of the task goroutines.
+// Labels set with pprof.WithLabels on ctx are kept
+func profilerLabels(ctx context.Context, info TaskInfo) context.Context {
+	labels := []string{"task_id", strconv.FormatUint(info.ID, 10)}
+	if info.Name != "" {
+		labels = append(labels, "task", info.Name)
+	}
+	if parent, ok := TaskInfoFromContext(ctx); ok {
+		labels = append(labels, "parent_task_id", strconv.FormatUint(parent.ID, 10))
+		if parent.Name != "" {
+			labels = append(labels, "parent_task", parent.Name)
+		}
+	}
+
+	return pprof.WithLabels(ctx, pprof.Labels(labels...))
+}
+
+// goLabeled runs f on a new goroutine labeled with labelsCtx
+func goLabeled(labelsCtx context.Context, f func()) {
+	go func() {
+		pprof.SetGoroutineLabels(labelsCtx)
+		f()
+	}()
+}
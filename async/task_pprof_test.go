@@ -0,0 +1,60 @@
+package async
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"runtime/pprof"
+	"testing"
+)
+
+func TestTask_ProfilerLabels(t *testing.T) {
+	parent := NewTask(nil, func() (int, error) {
+		return 1, nil
+	}, WithName("parent"))
+	ctx := pprof.WithLabels(parent.GetContext(), pprof.Labels("tenant", "acme"))
+
+	started, release := make(chan struct{}), make(chan struct{})
+	child := NewTask(ctx, func() (int, error) {
+		close(started)
+		<-release
+		return 1, nil
+	}, WithName("child"))
+	<-started
+
+	var profile bytes.Buffer
+	require.NoError(t, pprof.Lookup("goroutine").WriteTo(&profile, 1))
+	close(release)
+	_ = child.GetError()
+
+	parentInfo, ok := TaskInfoFromContext(parent.GetContext())
+	require.True(t, ok)
+	childInfo, ok := TaskInfoFromContext(child.GetContext())
+	require.True(t, ok)
+	require.Equal(t, parentInfo.ID, childInfo.ParentID)
+
+	for _, label := range []string{
+		`"task":"child"`,
+		fmt.Sprintf(`"task_id":"%d"`, childInfo.ID),
+		`"parent_task":"parent"`,
+		fmt.Sprintf(`"parent_task_id":"%d"`, parentInfo.ID),
+		`"tenant":"acme"`,
+	} {
+		require.Contains(t, profile.String(), label)
+	}
+}
+
+func TestTaskInfoFromContext(t *testing.T) {
+	_, ok := TaskInfoFromContext(context.TODO())
+	require.False(t, ok)
+
+	task := NewTask(nil, func() (int, error) {
+		return 1, nil
+	}, WithName("named"))
+	info, ok := TaskInfoFromContext(task.GetContext())
+
+	require.True(t, ok)
+	require.Equal(t, "named", info.Name)
+	require.Zero(t, info.ParentID)
+}
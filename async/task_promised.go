@@ -8,9 +8,10 @@ type taskPromised[T any] struct {
 	promised Task[T]
 }
 
-func FMap[T, U any](ctx context.Context, tsk Task[T], mapper func(data T) (U, error)) Task[U] {
+func FMap[T, U any](ctx context.Context, tsk Task[T], mapper func(data T) (U, error), opts ...TaskOption) Task[U] {
 	promisedChan := make(chan Task[U])
 
+	opts = append([]TaskOption{withSpanLinks(tsk.GetContext())}, opts...)
 	promised := NewTask(ctx, func() (result U, err error) {
 		var resolved T
 		resolved, err = tsk.Await()
@@ -20,7 +21,7 @@ func FMap[T, U any](ctx context.Context, tsk Task[T], mapper func(data T) (U, er
 		result, err = mapper(resolved)
 
 		return
-	})
+	}, opts...)
 
 	go func() {
 		promisedChan <- promised
@@ -33,19 +34,16 @@ func FMap[T, U any](ctx context.Context, tsk Task[T], mapper func(data T) (U, er
 				promised: promised,
 			}
 		case <-tsk.GetContext().Done():
-			var errCause error = ErrTaskContextCancelled
-			if err := context.Cause(tsk.GetContext()); err != nil {
-				errCause = err
-			}
-			return NewErrTask[U](tsk.GetContext(), errCause)
+			return newCancelledPromise[U](tsk.GetContext(), opts)
 		}
 	}
-	return NewErrTask[U](tsk.GetContext(), ErrTaskContextCancelled)
+	return newCancelledPromise[U](tsk.GetContext(), opts)
 }
 
-func FMap2[T1, T2, U any](ctx context.Context, tsk1 Task[T1], tsk2 Task[T2], mapper func(data1 T1, data2 T2) (U, error)) Task[U] {
+func FMap2[T1, T2, U any](ctx context.Context, tsk1 Task[T1], tsk2 Task[T2], mapper func(data1 T1, data2 T2) (U, error), opts ...TaskOption) Task[U] {
 	promisedChan := make(chan Task[U])
 
+	opts = append([]TaskOption{withSpanLinks(tsk1.GetContext(), tsk2.GetContext())}, opts...)
 	promised := NewTask(ctx, func() (result U, err error) {
 		var (
 			resolved1 T1
@@ -62,7 +60,7 @@ func FMap2[T1, T2, U any](ctx context.Context, tsk1 Task[T1], tsk2 Task[T2], map
 		result, err = mapper(resolved1, resolved2)
 
 		return
-	})
+	}, opts...)
 
 	go func() {
 		promisedChan <- promised
@@ -74,15 +72,16 @@ func FMap2[T1, T2, U any](ctx context.Context, tsk1 Task[T1], tsk2 Task[T2], map
 			promised: promised,
 		}
 	case <-tsk1.GetContext().Done():
-		return NewErrTask[U](tsk1.GetContext(), ErrTaskContextCancelled)
+		return newCancelledPromise[U](tsk1.GetContext(), opts)
 	case <-tsk2.GetContext().Done():
-		return NewErrTask[U](tsk2.GetContext(), ErrTaskContextCancelled)
+		return newCancelledPromise[U](tsk2.GetContext(), opts)
 	}
 }
 
-func FMap3[T1, T2, T3, U any](ctx context.Context, tsk1 Task[T1], tsk2 Task[T2], tsk3 Task[T3], mapper func(data1 T1, data2 T2, data3 T3) (U, error)) Task[U] {
+func FMap3[T1, T2, T3, U any](ctx context.Context, tsk1 Task[T1], tsk2 Task[T2], tsk3 Task[T3], mapper func(data1 T1, data2 T2, data3 T3) (U, error), opts ...TaskOption) Task[U] {
 	promisedChan := make(chan Task[U])
 
+	opts = append([]TaskOption{withSpanLinks(tsk1.GetContext(), tsk2.GetContext(), tsk3.GetContext())}, opts...)
 	promised := NewTask(ctx, func() (result U, err error) {
 		var (
 			resolved1 T1
@@ -104,7 +103,7 @@ func FMap3[T1, T2, T3, U any](ctx context.Context, tsk1 Task[T1], tsk2 Task[T2],
 		result, err = mapper(resolved1, resolved2, resolved3)
 
 		return
-	})
+	}, opts...)
 
 	go func() {
 		promisedChan <- promised
@@ -116,12 +115,23 @@ func FMap3[T1, T2, T3, U any](ctx context.Context, tsk1 Task[T1], tsk2 Task[T2],
 			promised: promised,
 		}
 	case <-tsk1.GetContext().Done():
-		return NewErrTask[U](tsk1.GetContext(), ErrTaskContextCancelled)
+		return newCancelledPromise[U](tsk1.GetContext(), opts)
 	case <-tsk2.GetContext().Done():
-		return NewErrTask[U](tsk2.GetContext(), ErrTaskContextCancelled)
+		return newCancelledPromise[U](tsk2.GetContext(), opts)
+	case <-tsk3.GetContext().Done():
+		return newCancelledPromise[U](tsk3.GetContext(), opts)
 	}
 }
 
+// newCancelledPromise is returned when a parent task is cancelled before the promised task is started
+func newCancelledPromise[U any](parentCtx context.Context, opts []TaskOption) Task[U] {
+	cfg := newTaskConfig(opts)
+	now := cfg.clock.Now()
+	err := newTaskError(parentCtx, newTaskInfo(parentCtx, cfg), now, now, ErrTaskContextCancelled)
+
+	return NewErrTask[U](parentCtx, err)
+}
+
 func (t *taskPromised[T]) Await() (res T, err error) {
 	if t.promised == nil {
 		err = ErrNilValueEncountered
@@ -131,13 +141,37 @@ func (t *taskPromised[T]) Await() (res T, err error) {
 }
 
 func (t *taskPromised[T]) Subscribe(cb func(data T, err error)) {
-	go cb(t.Await())
+	go cb(t.settle())
+}
+
+func (t *taskPromised[T]) settle() (res T, err error) {
+	if t.promised == nil {
+		err = ErrNilValueEncountered
+		return
+	}
+	return awaitSettled(t.promised)
 }
 
 func (t *taskPromised[T]) GetContext() context.Context {
 	return t.promised.GetContext()
 }
 
+func (t *taskPromised[T]) timing() (TaskTiming, bool) {
+	if t.promised == nil {
+		return TaskTiming{}, false
+	}
+
+	return TimingOf(t.promised)
+}
+
+func (t *taskPromised[T]) info() TaskInfo {
+	if provider, ok := t.promised.(taskInfoProvider); ok {
+		return provider.info()
+	}
+
+	return TaskInfo{}
+}
+
 func (t *taskPromised[T]) GetError() error {
 	_, err := t.Await()
 	return err
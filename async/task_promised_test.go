@@ -86,7 +86,7 @@ func TestMap(t *testing.T) {
 			promised := FMap(tc.ctx, task, tc.mapper)
 			result, err := promised.Await()
 			require.Equal(t, result, tc.expected)
-			require.Equal(t, err, tc.err)
+			require.ErrorIs(t, err, tc.err)
 		})
 	}
 }
@@ -0,0 +1,216 @@
+package async
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type TaskStatus string
+
+const (
+	TaskRunning TaskStatus = "running"
+	// TaskAbandoned tasks are resolved by cancellation or timeout while their function is still running
+	TaskAbandoned TaskStatus = "abandoned"
+	// TaskResolved tasks are reported only by Registry.Unawaited
+	TaskResolved TaskStatus = "resolved"
+)
+
+type TaskRecord struct {
+	ID      uint64        `json:"id"`
+	Name    string        `json:"name"`
+	Site    string        `json:"site"`
+	Created time.Time     `json:"created"`
+	Age     time.Duration `json:"age"`
+	Status  TaskStatus    `json:"status"`
+}
+
+// Registry keeps track of live tasks
+type Registry struct {
+	mtx         sync.RWMutex
+	tasks       map[uint64]*TaskRecord
+	trackAwaits bool
+	unawaited   map[uint64]*TaskRecord
+}
+
+type RegistryOption func(r *Registry)
+
+// TrackAwaits keeps the tasks that are never awaited until they are, see Registry.Unawaited
+func TrackAwaits() RegistryOption {
+	return func(r *Registry) {
+		r.trackAwaits = true
+	}
+}
+
+var defaultRegistry atomic.Pointer[Registry]
+
+func NewRegistry(opts ...RegistryOption) *Registry {
+	r := &Registry{
+		tasks:     make(map[uint64]*TaskRecord),
+		unawaited: make(map[uint64]*TaskRecord),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// SetRegistry registers tasks created afterward in r, nil disables registration
+func SetRegistry(r *Registry) {
+	defaultRegistry.Store(r)
+}
+
+func GetRegistry() *Registry {
+	return defaultRegistry.Load()
+}
+
+func WithRegistry(r *Registry) TaskOption {
+	return func(cfg *taskConfig) {
+		cfg.registry = r
+	}
+}
+
+// Snapshot returns the live tasks ordered by creation
+func (r *Registry) Snapshot() []TaskRecord {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	return snapshotRecords(r.tasks)
+}
+
+// Unawaited returns the tasks that were never awaited ordered by creation.
+// It is empty unless the registry is created with TrackAwaits
+func (r *Registry) Unawaited() []TaskRecord {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	return snapshotRecords(r.unawaited)
+}
+
+func snapshotRecords(records map[uint64]*TaskRecord) []TaskRecord {
+	now := time.Now()
+	result := make([]TaskRecord, 0, len(records))
+	for _, record := range records {
+		snapshot := *record
+		snapshot.Age = now.Sub(record.Created)
+		result = append(result, snapshot)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+
+	return result
+}
+
+func (r *Registry) Len() int {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	return len(r.tasks)
+}
+
+func (r *Registry) register(info TaskInfo) {
+	record := &TaskRecord{
+		ID:      info.ID,
+		Name:    info.Name,
+		Site:    creationSite(),
+		Created: time.Now(),
+		Status:  TaskRunning,
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	r.tasks[info.ID] = record
+	if r.trackAwaits {
+		r.unawaited[info.ID] = record
+	}
+}
+
+// resolved is called when the task is resolved
+func (r *Registry) resolved(id uint64) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if record, ok := r.tasks[id]; ok {
+		record.Status = TaskAbandoned
+	}
+}
+
+// returned is called when the function of the task returns
+func (r *Registry) returned(id uint64) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	delete(r.tasks, id)
+	if record, ok := r.unawaited[id]; ok {
+		record.Status = TaskResolved
+	}
+}
+
+// awaited is called when the result of the task is retrieved
+func (r *Registry) awaited(id uint64) {
+	if !r.trackAwaits {
+		return
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	delete(r.unawaited, id)
+}
+
+// creationSite returns the first caller outside of this package
+func creationSite() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	for {
+		frame, more := frames.Next()
+		internal := strings.HasPrefix(frame.Function, packagePath+".") && !strings.HasSuffix(frame.File, "_test.go")
+		if !internal {
+			return fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+const packagePath = "github.com/aybjax/aysync/async"
+
+// RegistryHandler serves the live tasks of r as HTML or as JSON with ?format=json
+func RegistryHandler(r *Registry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		records := r.Snapshot()
+
+		if req.URL.Query().Get("format") == "json" {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(records)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_ = registryTemplate.Execute(w, records)
+	})
+}
+
+var registryTemplate = template.Must(template.New("registry").Parse(`<!DOCTYPE html>
+<html>
+<head><title>aysync tasks</title></head>
+<body>
+<p>{{len .}} live tasks</p>
+<table>
+<tr><th>ID</th><th>Name</th><th>Status</th><th>Age</th><th>Created at</th></tr>
+{{range .}}<tr><td>{{.ID}}</td><td>{{.Name}}</td><td>{{.Status}}</td><td>{{.Age}}</td><td>{{.Site}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
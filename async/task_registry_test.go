@@ -0,0 +1,77 @@
+package async
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry()
+	release := make(chan struct{})
+	blocking := func() (int, error) {
+		<-release
+		return 1, nil
+	}
+	ctx, cancel := context.WithCancel(context.TODO())
+
+	running := NewTask(nil, blocking, WithName("running"), WithRegistry(registry))
+	abandoned := NewTask(ctx, blocking, WithName("abandoned"), WithRegistry(registry))
+	done := NewTask(nil, func() (int, error) {
+		return 1, nil
+	}, WithName("done"), WithRegistry(registry))
+	_ = done.GetError()
+	cancel()
+	_ = abandoned.GetError()
+
+	records := registry.Snapshot()
+	require.Len(t, records, 2)
+	require.Equal(t, "running", records[0].Name)
+	require.Equal(t, TaskRunning, records[0].Status)
+	require.Contains(t, records[0].Site, "task_registry_test.go")
+	require.Equal(t, "abandoned", records[1].Name)
+	require.Equal(t, TaskAbandoned, records[1].Status)
+	require.Greater(t, records[1].Age, time.Duration(0))
+
+	close(release)
+	_ = running.GetError()
+	require.Eventually(t, func() bool {
+		return registry.Len() == 0
+	}, time.Second, time.Millisecond)
+}
+
+func TestRegistryHandler(t *testing.T) {
+	registry := NewRegistry()
+	release := make(chan struct{})
+	defer close(release)
+	_ = NewTask(nil, func() (int, error) {
+		<-release
+		return 1, nil
+	}, WithName("<pending>"), WithRegistry(registry))
+
+	t.Run("json", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		RegistryHandler(registry).ServeHTTP(recorder, httptest.NewRequest("GET", "/debug/tasks?format=json", nil))
+
+		var records []TaskRecord
+		require.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(recorder.Body).Decode(&records))
+		require.Len(t, records, 1)
+		require.Equal(t, "<pending>", records[0].Name)
+		require.Equal(t, TaskRunning, records[0].Status)
+	})
+
+	t.Run("html", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		RegistryHandler(registry).ServeHTTP(recorder, httptest.NewRequest("GET", "/debug/tasks", nil))
+
+		body := recorder.Body.String()
+		require.True(t, strings.HasPrefix(recorder.Header().Get("Content-Type"), "text/html"))
+		require.Contains(t, body, "1 live tasks")
+		require.Contains(t, body, "&lt;pending&gt;")
+	})
+}
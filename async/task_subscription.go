@@ -0,0 +1,191 @@
+package async
+
+import "sync"
+
+type subscribeConfig struct {
+	executor func(f func())
+	serial   bool
+}
+
+type SubscribeOption func(cfg *subscribeConfig)
+
+// WithExecutor runs callbacks with executor instead of a goroutine per callback
+func WithExecutor(executor func(f func())) SubscribeOption {
+	return func(cfg *subscribeConfig) {
+		cfg.executor = executor
+	}
+}
+
+// Serially runs callbacks one by one in registration order
+func Serially() SubscribeOption {
+	return func(cfg *subscribeConfig) {
+		cfg.serial = true
+	}
+}
+
+// Observer dispatches the result of a task to its subscriptions
+type Observer[T any] struct {
+	cfg subscribeConfig
+
+	mtx       sync.Mutex
+	cond      *sync.Cond
+	resolved  bool
+	data      T
+	err       error
+	waiting   []*Subscription
+	queue     []*Subscription
+	draining  bool
+	remaining int
+}
+
+type Subscription struct {
+	mtx          sync.Mutex
+	cb           func()
+	dispatched   bool
+	unsubscribed bool
+	unsubscribe  func()
+}
+
+// Observe awaits task for its subscriptions, under PanicRepanic they get *PanicError as their error
+func Observe[T any](task Task[T], opts ...SubscribeOption) *Observer[T] {
+	o := &Observer[T]{
+		cfg: subscribeConfig{
+			executor: func(f func()) {
+				go f()
+			},
+		},
+	}
+	for _, opt := range opts {
+		opt(&o.cfg)
+	}
+	o.cond = sync.NewCond(&o.mtx)
+
+	go func() {
+		data, err := awaitSettled(task)
+
+		o.mtx.Lock()
+		o.resolved, o.data, o.err = true, data, err
+		o.enqueue(o.waiting...)
+		o.waiting = nil
+		o.cond.Broadcast()
+		o.mtx.Unlock()
+	}()
+
+	return o
+}
+
+// Subscribe registers cb to be called with the result of the task,
+// immediately if the task is already resolved
+func (o *Observer[T]) Subscribe(cb func(data T, err error)) *Subscription {
+	sub := &Subscription{}
+	sub.cb = func() {
+		cb(o.data, o.err)
+	}
+	sub.unsubscribe = func() {
+		o.mtx.Lock()
+		defer o.mtx.Unlock()
+
+		o.done()
+	}
+
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+
+	o.remaining++
+	if o.resolved {
+		o.enqueue(sub)
+	} else {
+		o.waiting = append(o.waiting, sub)
+	}
+
+	return sub
+}
+
+// Wait blocks until the task is resolved and the callbacks of all subscriptions have returned
+func (o *Observer[T]) Wait() {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+
+	for !o.resolved || o.remaining > 0 {
+		o.cond.Wait()
+	}
+}
+
+// enqueue must be called with o.mtx locked
+func (o *Observer[T]) enqueue(subs ...*Subscription) {
+	o.queue = append(o.queue, subs...)
+	if o.draining || len(o.queue) == 0 {
+		return
+	}
+
+	o.draining = true
+	go o.drain()
+}
+
+// drain dispatches queued subscriptions in registration order
+func (o *Observer[T]) drain() {
+	for {
+		o.mtx.Lock()
+		if len(o.queue) == 0 {
+			o.draining = false
+			o.mtx.Unlock()
+			return
+		}
+		sub := o.queue[0]
+		o.queue = o.queue[1:]
+		o.mtx.Unlock()
+
+		if !sub.dispatch() {
+			continue
+		}
+
+		run := func() {
+			defer func() {
+				o.mtx.Lock()
+				defer o.mtx.Unlock()
+
+				o.done()
+			}()
+
+			sub.cb()
+		}
+		if o.cfg.serial {
+			run()
+		} else {
+			o.cfg.executor(run)
+		}
+	}
+}
+
+// done must be called with o.mtx locked
+func (o *Observer[T]) done() {
+	o.remaining--
+	o.cond.Broadcast()
+}
+
+// dispatch reports whether the callback should run
+func (s *Subscription) dispatch() bool {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.unsubscribed {
+		return false
+	}
+	s.dispatched = true
+
+	return true
+}
+
+// Unsubscribe reports whether the callback was prevented from being called
+func (s *Subscription) Unsubscribe() bool {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.dispatched || s.unsubscribed {
+		return false
+	}
+	s.unsubscribed = true
+	s.unsubscribe()
+
+	return true
+}
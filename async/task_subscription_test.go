@@ -0,0 +1,106 @@
+package async
+
+import (
+	"errors"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestObserver(t *testing.T) {
+	err := errors.New("i am error")
+
+	t.Run("serial callbacks run in registration order", func(t *testing.T) {
+		release := make(chan struct{})
+		task := NewTask(nil, func() (int, error) {
+			<-release
+			return 1, nil
+		})
+		observer := Observe(task, Serially())
+
+		var calls []int
+		for i := 0; i < 5; i++ {
+			observer.Subscribe(func(data int, err error) {
+				calls = append(calls, i*data)
+			})
+		}
+		close(release)
+		observer.Subscribe(func(data int, err error) {
+			calls = append(calls, 5*data)
+		})
+		observer.Wait()
+
+		require.Equal(t, []int{0, 1, 2, 3, 4, 5}, calls)
+	})
+
+	t.Run("unsubscribed callback is not called", func(t *testing.T) {
+		release := make(chan struct{})
+		task := NewTask(nil, func() (int, error) {
+			<-release
+			return 0, err
+		})
+		observer := Observe(task)
+
+		var called, unsubscribedCalled atomic.Bool
+		observer.Subscribe(func(data int, cbErr error) {
+			called.Store(errors.Is(cbErr, err))
+		})
+		sub := observer.Subscribe(func(int, error) {
+			unsubscribedCalled.Store(true)
+		})
+		require.True(t, sub.Unsubscribe())
+		require.False(t, sub.Unsubscribe())
+		close(release)
+		observer.Wait()
+
+		require.True(t, called.Load())
+		require.False(t, unsubscribedCalled.Load())
+	})
+
+	t.Run("unsubscribe after dispatch", func(t *testing.T) {
+		observer := Observe(Resolved(nil, 1))
+		sub := observer.Subscribe(func(int, error) {})
+		observer.Wait()
+
+		require.False(t, sub.Unsubscribe())
+	})
+
+	t.Run("repanicking task", func(t *testing.T) {
+		o := Observe(NewTask(nil, panickingFunction("boom"), WithPanicPolicy(PanicRepanic)))
+		errs := make(chan error, 1)
+		o.Subscribe(func(_ int, err error) {
+			errs <- err
+		})
+
+		var panicErr *PanicError
+		require.ErrorAs(t, <-errs, &panicErr)
+	})
+
+	t.Run("executor runs callbacks", func(t *testing.T) {
+		var (
+			wg       sync.WaitGroup
+			executed atomic.Int32
+		)
+		observer := Observe(Resolved(nil, 1), WithExecutor(func(f func()) {
+			executed.Add(1)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				f()
+			}()
+		}))
+
+		var sum atomic.Int32
+		for i := 0; i < 3; i++ {
+			observer.Subscribe(func(data int, err error) {
+				sum.Add(int32(data))
+			})
+		}
+		observer.Wait()
+		wg.Wait()
+
+		require.Equal(t, int32(3), executed.Load())
+		require.Equal(t, int32(3), sum.Load())
+	})
+}
@@ -0,0 +1,110 @@
+package async
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+type Span interface {
+	AddEvent(name string)
+	RecordError(err error)
+	End()
+}
+
+// Tracer starts a span as a child of the span in ctx.
+// links are spans of the tasks the new task depends on
+type Tracer interface {
+	Start(ctx context.Context, name string, links []Span) (context.Context, Span)
+}
+
+const (
+	SpanEventPanic     = "panic"
+	SpanEventTimeout   = "timeout"
+	SpanEventCancelled = "cancelled"
+)
+
+type spanCtxKey struct{}
+
+var defaultTracer atomic.Pointer[Tracer]
+
+func SetTracer(tracer Tracer) {
+	if tracer == nil {
+		defaultTracer.Store(nil)
+		return
+	}
+	defaultTracer.Store(&tracer)
+}
+
+func WithTracer(tracer Tracer) TaskOption {
+	return func(cfg *taskConfig) {
+		cfg.tracer = tracer
+	}
+}
+
+// withSpanLinks links the span of the task to the spans in ctxs
+func withSpanLinks(ctxs ...context.Context) TaskOption {
+	return func(cfg *taskConfig) {
+		for _, ctx := range ctxs {
+			if span := SpanFromContext(ctx); span != nil {
+				cfg.spanLinks = append(cfg.spanLinks, span)
+			}
+		}
+	}
+}
+
+func ContextWithSpan(ctx context.Context, span Span) context.Context {
+	return context.WithValue(ctx, spanCtxKey{}, span)
+}
+
+func SpanFromContext(ctx context.Context) Span {
+	if ctx == nil {
+		return nil
+	}
+	span, _ := ctx.Value(spanCtxKey{}).(Span)
+
+	return span
+}
+
+func globalTracer() Tracer {
+	if tracer := defaultTracer.Load(); tracer != nil {
+		return *tracer
+	}
+
+	return nil
+}
+
+func startSpan(ctx context.Context, cfg *taskConfig, info TaskInfo) (context.Context, Span) {
+	if cfg.tracer == nil {
+		return ctx, nil
+	}
+
+	name := info.Name
+	if name == "" {
+		name = "task"
+	}
+	ctx, span := cfg.tracer.Start(ctx, name, cfg.spanLinks)
+	if span == nil {
+		return ctx, nil
+	}
+
+	return ContextWithSpan(ctx, span), span
+}
+
+func endSpan(span Span, outcome Outcome, err error) {
+	if span == nil {
+		return
+	}
+
+	switch outcome {
+	case OutcomePanicked:
+		span.AddEvent(SpanEventPanic)
+	case OutcomeTimedOut:
+		span.AddEvent(SpanEventTimeout)
+	case OutcomeCancelled:
+		span.AddEvent(SpanEventCancelled)
+	}
+	if err != nil {
+		span.RecordError(err)
+	}
+	span.End()
+}
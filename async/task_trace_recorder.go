@@ -0,0 +1,104 @@
+package async
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SpanRecorder is an in-memory Tracer
+type SpanRecorder struct {
+	lastID atomic.Uint64
+	mtx    sync.Mutex
+	ended  []RecordedSpan
+}
+
+type RecordedSpan struct {
+	ID       uint64
+	ParentID uint64
+	Name     string
+	Links    []uint64
+	Events   []string
+	Errors   []error
+	Start    time.Time
+	End      time.Time
+}
+
+type recordingSpan struct {
+	recorder *SpanRecorder
+	mtx      sync.Mutex
+	data     RecordedSpan
+	ended    bool
+}
+
+func NewSpanRecorder() *SpanRecorder {
+	return &SpanRecorder{}
+}
+
+func (r *SpanRecorder) Start(ctx context.Context, name string, links []Span) (context.Context, Span) {
+	span := &recordingSpan{
+		recorder: r,
+		data: RecordedSpan{
+			ID:    r.lastID.Add(1),
+			Name:  name,
+			Start: time.Now(),
+		},
+	}
+	if parent, ok := SpanFromContext(ctx).(*recordingSpan); ok {
+		span.data.ParentID = parent.data.ID
+	}
+	for _, link := range links {
+		if linked, ok := link.(*recordingSpan); ok {
+			span.data.Links = append(span.data.Links, linked.data.ID)
+		}
+	}
+
+	return ctx, span
+}
+
+// Spans returns the ended spans in the order they ended
+func (r *SpanRecorder) Spans() []RecordedSpan {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	return append([]RecordedSpan{}, r.ended...)
+}
+
+func (r *SpanRecorder) Reset() {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	r.ended = nil
+}
+
+func (s *recordingSpan) AddEvent(name string) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.data.Events = append(s.data.Events, name)
+}
+
+func (s *recordingSpan) RecordError(err error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.data.Errors = append(s.data.Errors, err)
+}
+
+func (s *recordingSpan) End() {
+	s.mtx.Lock()
+	if s.ended {
+		s.mtx.Unlock()
+		return
+	}
+	s.ended = true
+	s.data.End = time.Now()
+	data := s.data
+	s.mtx.Unlock()
+
+	s.recorder.mtx.Lock()
+	defer s.recorder.mtx.Unlock()
+
+	s.recorder.ended = append(s.recorder.ended, data)
+}
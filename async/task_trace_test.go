@@ -0,0 +1,113 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func spanByName(t *testing.T, recorder *SpanRecorder, name string) RecordedSpan {
+	for _, span := range recorder.Spans() {
+		if span.Name == name {
+			return span
+		}
+	}
+	t.Fatalf("span %q is not recorded", name)
+
+	return RecordedSpan{}
+}
+
+func TestTask_Tracing(t *testing.T) {
+	err := errors.New("i am error")
+
+	t.Run("child of span in context", func(t *testing.T) {
+		recorder := NewSpanRecorder()
+		ctx, root := recorder.Start(context.TODO(), "root", nil)
+		ctx = ContextWithSpan(ctx, root)
+
+		task := NewTask(ctx, func() (int, error) {
+			return 1, nil
+		}, WithName("child"), WithTracer(recorder))
+		_, _ = task.Await()
+		root.End()
+
+		rootSpan := spanByName(t, recorder, "root")
+		childSpan := spanByName(t, recorder, "child")
+		require.Equal(t, rootSpan.ID, childSpan.ParentID)
+		require.Empty(t, childSpan.Events)
+		require.Empty(t, childSpan.Errors)
+	})
+
+	t.Run("promised task links input tasks", func(t *testing.T) {
+		recorder := NewSpanRecorder()
+		task1 := NewTask(nil, func() (int, error) {
+			return 1, nil
+		}, WithName("input1"), WithTracer(recorder))
+		task2 := NewTask(nil, func() (int, error) {
+			return 2, nil
+		}, WithName("input2"), WithTracer(recorder))
+		promised := FMap2(nil, task1, task2, func(i1, i2 int) (int, error) {
+			return i1 + i2, nil
+		}, WithName("sum"), WithTracer(recorder))
+
+		result, err := promised.Await()
+
+		require.NoError(t, err)
+		require.Equal(t, 3, result)
+		require.Equal(t, []uint64{
+			spanByName(t, recorder, "input1").ID,
+			spanByName(t, recorder, "input2").ID,
+		}, spanByName(t, recorder, "sum").Links)
+	})
+
+	testCases := []struct {
+		name   string
+		ctx    func() context.Context
+		f      func() (int, error)
+		events []string
+	}{
+		{
+			name: "error",
+			f: func() (int, error) {
+				return 0, err
+			},
+		},
+		{
+			name:   "panic",
+			f:      panickingFunction("boom"),
+			events: []string{SpanEventPanic},
+		},
+		{
+			name: "cancelled",
+			ctx: func() context.Context {
+				ctx, cancel := context.WithCancel(context.TODO())
+				cancel()
+				return ctx
+			},
+			f: func() (int, error) {
+				time.Sleep(time.Second)
+				return 0, nil
+			},
+			events: []string{SpanEventCancelled},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := NewSpanRecorder()
+			var ctx context.Context
+			if tc.ctx != nil {
+				ctx = tc.ctx()
+			}
+			task := NewTask(ctx, tc.f, WithName(tc.name), WithTracer(recorder))
+			_, awaitErr := task.Await()
+
+			span := spanByName(t, recorder, tc.name)
+			require.Equal(t, tc.events, span.Events)
+			require.Len(t, span.Errors, 1)
+			require.Error(t, awaitErr)
+		})
+	}
+}
@@ -2,6 +2,9 @@ package async
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 )
 import "golang.org/x/sync/errgroup"
 
@@ -20,25 +23,45 @@ func AreValid(ctx context.Context, tasks ...taskAny) error {
 	for _, task := range tasks {
 		task := task
 		g.Go(func() error {
-			done := make(chan error)
+			done := make(chan error, 1)
 			go func() {
-				err := task.GetError()
-				done <- err
+				defer func() {
+					if excp := recover(); excp != nil {
+						done <- &recoveredPanic{value: excp}
+					}
+				}()
+				done <- task.GetError()
 			}()
 			select {
 			case <-gCtx.Done():
 				return nil
 			case err := <-done:
-				return err
+				if _, ok := err.(*recoveredPanic); ok {
+					return err
+				}
+				return asTaskError(task, err)
 			}
 
 		})
 	}
 	err := g.Wait()
+	if panicked, ok := err.(*recoveredPanic); ok {
+		// tasks re-panicking under PanicRepanic panic on the goroutine calling AreValid
+		panic(panicked.value)
+	}
 
 	return err
 }
 
+// recoveredPanic carries a panic recovered while awaiting a task to the goroutine that awaits it
+type recoveredPanic struct {
+	value any
+}
+
+func (p *recoveredPanic) Error() string {
+	return fmt.Sprintf("panic while awaiting task: %v", p.value)
+}
+
 func MapOnValid[T any](ctx context.Context, generator func() (T, error), tasks ...taskAny) Task[T] {
 	if generator == nil {
 		return NewErrTask[T](ctx, ErrNilFuncEncountered)
@@ -51,3 +74,23 @@ func MapOnValid[T any](ctx context.Context, generator func() (T, error), tasks .
 
 	return NewTask(ctx, generator)
 }
+
+func asTaskError(task taskAny, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var taskErr *TaskError
+	if errors.As(err, &taskErr) {
+		return err
+	}
+
+	var taskInfo TaskInfo
+	if provider, ok := task.(taskInfoProvider); ok {
+		taskInfo = provider.info()
+	}
+
+	now := time.Now()
+
+	return newTaskError(nil, taskInfo, now, now, err)
+}
@@ -3,17 +3,29 @@ package async
 import "context"
 
 type valueTask[T any] struct {
-	ctx       context.Context
-	otherwise T
-	err       error
+	ctx  context.Context
+	data T
+	err  error
+}
+
+// Resolved returns an already resolved task, no goroutine is started
+func Resolved[T any](ctx context.Context, data T) Task[T] {
+	if ctx == nil {
+		ctx = context.TODO()
+	}
+
+	return &valueTask[T]{
+		ctx:  ctx,
+		data: data,
+	}
 }
 
 func (t *valueTask[T]) Await() (T, error) {
-	return t.otherwise, t.err
+	return t.data, t.err
 }
 
 func (t *valueTask[T]) Subscribe(cb func(data T, err error)) {
-	go cb(t.otherwise, nil)
+	go cb(t.data, t.err)
 }
 
 func (t *valueTask[T]) GetContext() context.Context {
@@ -0,0 +1,45 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"strconv"
+	"testing"
+)
+
+func TestResolved(t *testing.T) {
+	task := Resolved(nil, 1)
+
+	result, err := task.Await()
+	require.NoError(t, err)
+	require.Equal(t, 1, result)
+	require.NoError(t, task.GetError())
+	require.NotNil(t, task.GetContext())
+
+	promised := FMap(nil, task, func(i int) (string, error) {
+		return strconv.Itoa(i), nil
+	})
+	mapped, err := promised.Await()
+	require.NoError(t, err)
+	require.Equal(t, "1", mapped)
+
+	require.NoError(t, AreValid(nil, task, Resolved(context.TODO(), "value")))
+}
+
+func TestValueTask_Subscribe(t *testing.T) {
+	err := errors.New("i am error")
+	task := TernFunc(nil, false, func() (int, error) {
+		return 1, nil
+	}, func() (int, error) {
+		return 2, err
+	})
+
+	done := make(chan error)
+	task.Subscribe(func(data int, err error) {
+		require.Equal(t, 2, data)
+		done <- err
+	})
+
+	require.Equal(t, err, <-done)
+}
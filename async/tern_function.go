@@ -2,34 +2,56 @@ package async
 
 import "context"
 
-func Tern[T any](ctx context.Context, cond bool, taskGen func() (T, error), otherwise T) Task[T] {
+func Tern[T any](ctx context.Context, cond bool, taskGen func() (T, error), otherwise T, opts ...TaskOption) Task[T] {
 	if !cond {
-		return &valueTask[T]{
-			ctx:       ctx,
-			otherwise: otherwise,
-		}
+		return Resolved(ctx, otherwise)
 	}
 
-	return NewTask[T](ctx, taskGen)
+	return NewTask[T](ctx, taskGen, opts...)
 }
 
-func TernFunc[T any](ctx context.Context, cond bool, taskGen func() (T, error), otherwiseGen func() (T, error)) Task[T] {
+func TernFunc[T any](ctx context.Context, cond bool, taskGen func() (T, error), otherwiseGen func() (T, error), opts ...TaskOption) Task[T] {
 	if !cond {
 		otherwise, err := otherwiseGen()
+		if ctx == nil {
+			ctx = context.TODO()
+		}
 		return &valueTask[T]{
-			ctx:       ctx,
-			otherwise: otherwise,
-			err:       err,
+			ctx:  ctx,
+			data: otherwise,
+			err:  err,
 		}
 	}
 
-	return NewTask[T](ctx, taskGen)
+	return NewTask[T](ctx, taskGen, opts...)
 }
 
-func TernTask[T any](ctx context.Context, cond bool, taskGen func() (T, error), otherwiseTaskGen func() (T, error)) Task[T] {
+func TernTask[T any](ctx context.Context, cond bool, taskGen func() (T, error), otherwiseTaskGen func() (T, error), opts ...TaskOption) Task[T] {
 	if !cond {
-		return NewTask[T](ctx, otherwiseTaskGen)
+		return NewTask[T](ctx, otherwiseTaskGen, opts...)
 	}
 
-	return NewTask[T](ctx, taskGen)
+	return NewTask[T](ctx, taskGen, opts...)
+}
+
+func TernAsync[T any](ctx context.Context, cond Task[bool], taskGen func() (T, error), otherwiseTaskGen func() (T, error), opts ...TaskOption) Task[T] {
+	if cond == nil {
+		return NewErrTask[T](ctx, ErrNilValueEncountered)
+	}
+	if taskGen == nil || otherwiseTaskGen == nil {
+		return NewErrTask[T](ctx, ErrNilFuncEncountered)
+	}
+
+	return NewTask[T](ctx, func() (T, error) {
+		ok, err := cond.Await()
+		if err != nil {
+			var zero T
+			return zero, err
+		}
+		if ok {
+			return taskGen()
+		}
+
+		return otherwiseTaskGen()
+	}, opts...)
 }
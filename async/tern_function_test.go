@@ -62,7 +62,7 @@ func TestTern(t *testing.T) {
 
 			result, err := tsk.Await()
 			require.Equal(t, result, tc.result)
-			require.Equal(t, err, tc.err)
+			require.ErrorIs(t, err, tc.err)
 		})
 	}
 }
@@ -142,7 +142,7 @@ func TestTernFunc(t *testing.T) {
 			result, err := tsk.Await()
 
 			require.Equal(t, result, tc.result)
-			require.Equal(t, err, tc.err)
+			require.ErrorIs(t, err, tc.err)
 		})
 	}
 }
@@ -222,7 +222,110 @@ func TestTernTask(t *testing.T) {
 			result, err := tsk.Await()
 
 			require.Equal(t, result, tc.result)
-			require.Equal(t, err, tc.err)
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestTernAsync(t *testing.T) {
+	ctx := context.TODO()
+	taskRes := 1
+	otherwiseRes := 2
+	err := errors.New("i am an error")
+	testCases := []struct {
+		name      string
+		ctx       context.Context
+		condition func() (bool, error)
+		taskGen   func() (int, error)
+		otherwise func() (int, error)
+		result    int
+		err       error
+	}{
+		{
+			name: "true case: task runs",
+			ctx:  ctx,
+			condition: func() (bool, error) {
+				return true, nil
+			},
+			taskGen: func() (int, error) {
+				return taskRes, nil
+			},
+			otherwise: func() (int, error) {
+				return otherwiseRes, nil
+			},
+			result: taskRes,
+			err:    nil,
+		},
+		{
+			name: "false case: otherwise runs",
+			ctx:  ctx,
+			condition: func() (bool, error) {
+				return false, nil
+			},
+			taskGen: func() (int, error) {
+				return taskRes, nil
+			},
+			otherwise: func() (int, error) {
+				return otherwiseRes, nil
+			},
+			result: otherwiseRes,
+			err:    nil,
+		},
+		{
+			name: "condition errors: no branch runs",
+			ctx:  ctx,
+			condition: func() (bool, error) {
+				return false, err
+			},
+			taskGen: func() (int, error) {
+				t.Error("task branch should not run")
+				return taskRes, nil
+			},
+			otherwise: func() (int, error) {
+				t.Error("otherwise branch should not run")
+				return otherwiseRes, nil
+			},
+			result: 0,
+			err:    err,
+		},
+		{
+			name: "false case: otherwise errors",
+			ctx:  ctx,
+			condition: func() (bool, error) {
+				return false, nil
+			},
+			taskGen: func() (int, error) {
+				return taskRes, nil
+			},
+			otherwise: func() (int, error) {
+				return otherwiseRes, err
+			},
+			result: otherwiseRes,
+			err:    err,
+		},
+		{
+			name: "nil otherwise",
+			ctx:  ctx,
+			condition: func() (bool, error) {
+				return true, nil
+			},
+			taskGen: func() (int, error) {
+				return taskRes, nil
+			},
+			result: 0,
+			err:    ErrNilFuncEncountered,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cond := NewTask(tc.ctx, tc.condition)
+			tsk := TernAsync(tc.ctx, cond, tc.taskGen, tc.otherwise)
+
+			result, err := tsk.Await()
+
+			require.Equal(t, result, tc.result)
+			require.ErrorIs(t, err, tc.err)
 		})
 	}
 }
@@ -0,0 +1,104 @@
+package asynctest
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aybjax/aysync/async"
+)
+
+var (
+	// AwaitTimeout bounds how long assertions wait for a task to be resolved
+	AwaitTimeout = time.Second
+	// PendingWait is how long AssertPending waits for a task to stay unresolved
+	PendingWait = 10 * time.Millisecond
+)
+
+type settledChecker interface {
+	Settled() bool
+}
+
+type awaitResult[T any] struct {
+	data T
+	err  error
+}
+
+func await[T any](task async.Task[T], timeout time.Duration) (awaitResult[T], bool) {
+	results := make(chan awaitResult[T], 1)
+	go func() {
+		data, err := task.Await()
+		results <- awaitResult[T]{data: data, err: err}
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case result := <-results:
+		return result, true
+	case <-timer.C:
+		return awaitResult[T]{}, false
+	}
+}
+
+func AssertResolvesTo[T any](t testing.TB, task async.Task[T], expected T) bool {
+	t.Helper()
+
+	result, ok := await(task, AwaitTimeout)
+	if !ok {
+		t.Errorf("task is not resolved within %s", AwaitTimeout)
+		return false
+	}
+	if result.err != nil {
+		t.Errorf("task is rejected with %v, expected to resolve to %v", result.err, expected)
+		return false
+	}
+	if !reflect.DeepEqual(result.data, expected) {
+		t.Errorf("task resolved to %v, expected %v", result.data, expected)
+		return false
+	}
+
+	return true
+}
+
+// AssertRejectsWith checks that the task is rejected with an error matching target with errors.Is
+func AssertRejectsWith[T any](t testing.TB, task async.Task[T], target error) bool {
+	t.Helper()
+
+	result, ok := await(task, AwaitTimeout)
+	if !ok {
+		t.Errorf("task is not rejected within %s", AwaitTimeout)
+		return false
+	}
+	if result.err == nil {
+		t.Errorf("task resolved to %v, expected to be rejected with %v", result.data, target)
+		return false
+	}
+	if !errors.Is(result.err, target) {
+		t.Errorf("task is rejected with %v, expected %v", result.err, target)
+		return false
+	}
+
+	return true
+}
+
+func AssertPending[T any](t testing.TB, task async.Task[T]) bool {
+	t.Helper()
+
+	if checker, ok := task.(settledChecker); ok {
+		if checker.Settled() {
+			t.Errorf("task is settled, expected to be pending")
+			return false
+		}
+		return true
+	}
+
+	if result, ok := await(task, PendingWait); ok {
+		t.Errorf("task is settled with (%v, %v), expected to be pending", result.data, result.err)
+		return false
+	}
+
+	return true
+}
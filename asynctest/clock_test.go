@@ -0,0 +1,194 @@
+package asynctest
+
+import (
+	"errors"
+	"github.com/aybjax/aysync/async"
+	"github.com/stretchr/testify/require"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+
+	late := clock.NewTimer(2 * time.Second)
+	early := clock.NewTimer(time.Second)
+	stopped := clock.NewTimer(time.Second)
+	require.Equal(t, 3, clock.Timers())
+	require.True(t, stopped.Stop())
+	require.False(t, stopped.Stop())
+
+	clock.Advance(time.Second)
+	require.Equal(t, start.Add(time.Second), clock.Now())
+	require.Equal(t, start.Add(time.Second), <-early.C())
+	select {
+	case <-late.C():
+		t.Error("timer fired before its deadline")
+	default:
+	}
+
+	clock.Advance(time.Hour)
+	require.Equal(t, start.Add(2*time.Second), <-late.C())
+	require.Zero(t, clock.Timers())
+}
+
+func TestFakeClock_TaskTimeout(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	release := make(chan struct{})
+	defer close(release)
+
+	task := async.NewTask(nil, func() (int, error) {
+		<-release
+		return 1, nil
+	}, async.WithClock(clock), async.WithTimeout(time.Minute))
+	clock.BlockUntil(1)
+	clock.Advance(time.Minute)
+
+	_, err := task.Await()
+	require.ErrorIs(t, err, async.ErrTaskTimeout)
+
+	var taskErr *async.TaskError
+	require.ErrorAs(t, err, &taskErr)
+	require.Equal(t, time.Minute, taskErr.Duration())
+}
+
+func TestFakeClock_Poll(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	var attempts atomic.Int32
+
+	task := async.Poll(nil, func() (int32, error) {
+		return attempts.Add(1), nil
+	}, time.Second, func(attempt int32) bool {
+		return attempt == 3
+	}, async.WithClock(clock))
+
+	for i := 1; i < 3; i++ {
+		clock.BlockUntil(1)
+		require.Equal(t, int32(i), attempts.Load())
+		clock.Advance(time.Second)
+	}
+
+	result, err := task.Await()
+	require.NoError(t, err)
+	require.Equal(t, int32(3), result)
+}
+
+func TestFakeClock_WhileDeadline(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	var iterations atomic.Int32
+
+	task := async.While(nil, func() bool {
+		return true
+	}, func() (int32, error) {
+		return iterations.Add(1), nil
+	}, async.LoopLimits{
+		Interval: 10 * time.Second,
+		Deadline: clock.Now().Add(25 * time.Second),
+	}, async.WithClock(clock))
+
+	for i := 1; i < 3; i++ {
+		clock.BlockUntil(1)
+		clock.Advance(10 * time.Second)
+	}
+
+	result, err := task.Await()
+	require.ErrorIs(t, err, async.ErrLoopDeadline)
+	require.Equal(t, int32(3), result)
+}
+
+func TestFakeClock_Delay(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	source := NewControlled[int](nil)
+
+	delayed := async.Delay(nil, time.Second, "value", async.WithClock(clock))
+	slept := async.Sleep(nil, time.Second, async.WithClock(clock))
+	delayedTask := async.DelayTask(nil, time.Second, source, async.WithClock(clock))
+
+	clock.BlockUntil(3)
+	AssertPending(t, delayed)
+	AssertPending(t, slept)
+	AssertPending(t, delayedTask)
+
+	source.Resolve(1)
+	clock.Advance(time.Second)
+
+	AssertResolvesTo(t, delayed, "value")
+	AssertResolvesTo(t, slept, struct{}{})
+	AssertResolvesTo(t, delayedTask, 1)
+}
+
+func TestFakeClock_MultipleTasks(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	tasks := make([]async.Task[int], 10)
+	for i := range tasks {
+		tasks[i] = async.Delay(nil, 500*time.Millisecond, i, async.WithClock(clock))
+	}
+
+	// a single advance resolves all of the tasks as they run concurrently
+	clock.BlockUntil(len(tasks))
+	clock.Advance(500 * time.Millisecond)
+
+	for i, task := range tasks {
+		AssertResolvesTo(t, task, i)
+	}
+}
+
+func TestFakeClock_AreValid(t *testing.T) {
+	err := errors.New("me, I am an error")
+	failAfter := func(clock *FakeClock, d time.Duration) async.Task[int] {
+		return async.NewTask(nil, func() (int, error) {
+			<-clock.NewTimer(d).C()
+			return 0, err
+		})
+	}
+	areValid := func(tasks ...async.Task[int]) async.Task[struct{}] {
+		return async.NewTask(nil, func() (struct{}, error) {
+			return struct{}{}, async.AreValid(nil, tasks[0], tasks[1])
+		})
+	}
+
+	t.Run("waits for all tasks", func(t *testing.T) {
+		clock := NewFakeClock(time.Now())
+		first := async.Delay(nil, time.Second, 0, async.WithClock(clock))
+		second := async.Delay(nil, 2*time.Second, 0, async.WithClock(clock))
+		valid := areValid(first, second)
+
+		clock.BlockUntil(2)
+		clock.Advance(time.Second)
+		AssertPending(t, valid)
+
+		clock.Advance(time.Second)
+		AssertResolvesTo(t, valid, struct{}{})
+	})
+
+	t.Run("returns on the first error", func(t *testing.T) {
+		clock := NewFakeClock(time.Now())
+		slow := async.Delay(nil, time.Second, 0, async.WithClock(clock))
+		valid := areValid(slow, failAfter(clock, 50*time.Millisecond))
+
+		clock.BlockUntil(2)
+		clock.Advance(50 * time.Millisecond)
+
+		AssertRejectsWith(t, valid, err)
+		AssertPending(t, slow)
+	})
+
+	t.Run("MapOnValid runs the generator after the tasks", func(t *testing.T) {
+		clock := NewFakeClock(time.Now())
+		first := async.Delay(nil, time.Second, 0, async.WithClock(clock))
+		second := async.Delay(nil, time.Second, 0, async.WithClock(clock))
+		mapped := async.NewTask(nil, func() (int, error) {
+			return async.MapOnValid(nil, func() (int, error) {
+				return 1, nil
+			}, first, second).Await()
+		})
+
+		clock.BlockUntil(2)
+		AssertPending(t, mapped)
+		clock.Advance(time.Second)
+
+		AssertResolvesTo(t, mapped, 1)
+	})
+}
@@ -0,0 +1,138 @@
+package asynctest
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aybjax/aysync/async"
+)
+
+const asyncPackage = "github.com/aybjax/aysync/async."
+
+type leakConfig struct {
+	timeout         time.Duration
+	reportUnawaited bool
+}
+
+type LeakOption func(cfg *leakConfig)
+
+// WithLeakTimeout sets how long goroutines are given to exit, one second by default
+func WithLeakTimeout(timeout time.Duration) LeakOption {
+	return func(cfg *leakConfig) {
+		cfg.timeout = timeout
+	}
+}
+
+// ReportUnawaited reports tasks created during the test that were never awaited.
+// It replaces the registry of the async package for the duration of the test,
+// so it should not be used by parallel tests
+func ReportUnawaited() LeakOption {
+	return func(cfg *leakConfig) {
+		cfg.reportUnawaited = true
+	}
+}
+
+// VerifyNoLeaks fails t if goroutines of the async package started during the test
+// are still running when the test ends
+func VerifyNoLeaks(t testing.TB, opts ...LeakOption) {
+	t.Helper()
+
+	cfg := &leakConfig{
+		timeout: time.Second,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	before := make(map[string]bool)
+	for _, g := range asyncGoroutines() {
+		before[g.id] = true
+	}
+
+	var registry *async.Registry
+	if cfg.reportUnawaited {
+		prev := async.GetRegistry()
+		registry = async.NewRegistry(async.TrackAwaits())
+		async.SetRegistry(registry)
+		t.Cleanup(func() {
+			async.SetRegistry(prev)
+		})
+	}
+
+	t.Cleanup(func() {
+		t.Helper()
+
+		if registry != nil {
+			for _, record := range registry.Unawaited() {
+				t.Errorf("task %s was never awaited, created at %s", describeTask(record), record.Site)
+			}
+		}
+
+		var leaked []goroutine
+		deadline := time.Now().Add(cfg.timeout)
+		for {
+			leaked = leaked[:0]
+			for _, g := range asyncGoroutines() {
+				if !before[g.id] {
+					leaked = append(leaked, g)
+				}
+			}
+			if len(leaked) == 0 || time.Now().After(deadline) {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		for _, g := range leaked {
+			t.Errorf("leaked goroutine %s", g.stack)
+		}
+	})
+}
+
+func describeTask(record async.TaskRecord) string {
+	if record.Name == "" {
+		return fmt.Sprintf("#%d", record.ID)
+	}
+
+	return fmt.Sprintf("%q #%d", record.Name, record.ID)
+}
+
+type goroutine struct {
+	id    string
+	stack string
+}
+
+// asyncGoroutines returns the goroutines running code of the async package, except the current one
+func asyncGoroutines() []goroutine {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+
+	var result []goroutine
+	// the current goroutine is listed first
+	for _, stack := range strings.Split(string(buf), "\n\n")[1:] {
+		if !strings.Contains(stack, asyncPackage) {
+			continue
+		}
+		header, _, _ := strings.Cut(stack, "\n")
+		fields := strings.Fields(header)
+		if len(fields) < 2 {
+			continue
+		}
+		result = append(result, goroutine{
+			id:    fields[1],
+			stack: stack,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,89 @@
+package asynctest
+
+import (
+	"context"
+	"fmt"
+	"github.com/aybjax/aysync/async"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+// recordingTB collects failures and cleanups instead of reporting them
+type recordingTB struct {
+	testing.TB
+	errors   []string
+	cleanups []func()
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recordingTB) Cleanup(f func()) {
+	r.cleanups = append(r.cleanups, f)
+}
+
+func (r *recordingTB) finish() {
+	for i := len(r.cleanups) - 1; i >= 0; i-- {
+		r.cleanups[i]()
+	}
+}
+
+func TestVerifyNoLeaks(t *testing.T) {
+	t.Run("no leaks", func(t *testing.T) {
+		tb := &recordingTB{TB: t}
+		VerifyNoLeaks(tb)
+
+		task := async.NewTask(nil, func() (int, error) {
+			return 1, nil
+		})
+		_, _ = task.Await()
+		ctx, cancel := context.WithCancel(context.TODO())
+		for range async.ForItem(ctx, []int{1, 2, 3}) {
+			break
+		}
+		cancel()
+
+		tb.finish()
+		require.Empty(t, tb.errors)
+	})
+
+	t.Run("leaked task", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+		tb := &recordingTB{TB: t}
+		VerifyNoLeaks(tb, WithLeakTimeout(50*time.Millisecond))
+
+		_ = async.NewTask(nil, func() (int, error) {
+			<-release
+			return 1, nil
+		})
+
+		tb.finish()
+		require.NotEmpty(t, tb.errors)
+		require.True(t, strings.HasPrefix(tb.errors[0], "leaked goroutine"))
+	})
+
+	t.Run("unawaited task", func(t *testing.T) {
+		tb := &recordingTB{TB: t}
+		VerifyNoLeaks(tb, ReportUnawaited())
+
+		awaited := async.NewTask(nil, func() (int, error) {
+			return 1, nil
+		}, async.WithName("awaited"))
+		_ = awaited.GetError()
+		_ = async.NewTask(nil, func() (int, error) {
+			return 1, nil
+		}, async.WithName("forgotten"))
+
+		tb.finish()
+		require.Len(t, tb.errors, 1)
+		require.Contains(t, tb.errors[0], `task "forgotten"`)
+		require.Contains(t, tb.errors[0], "leak_test.go")
+		require.Nil(t, async.GetRegistry())
+	})
+}
@@ -0,0 +1,141 @@
+package asynctest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aybjax/aysync/async"
+)
+
+// Controlled is a task resolved by the test with Resolve or Reject.
+// Like tasks of the async package it is resolved with async.ErrTaskContextCancelled
+// when its context is done and its context is cancelled when it is rejected
+type Controlled[T any] struct {
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+	once   sync.Once
+	done   chan struct{}
+	data   T
+	err    error
+}
+
+var _ async.Task[int] = (*Controlled[int])(nil)
+
+func NewControlled[T any](ctx context.Context) *Controlled[T] {
+	if ctx == nil {
+		ctx = context.TODO()
+	}
+	ctx, cancel := context.WithCancelCause(ctx)
+
+	return &Controlled[T]{
+		ctx:    ctx,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+}
+
+func Resolved[T any](ctx context.Context, data T) async.Task[T] {
+	c := NewControlled[T](ctx)
+	c.Resolve(data)
+
+	return c
+}
+
+func Rejected[T any](ctx context.Context, err error) async.Task[T] {
+	c := NewControlled[T](ctx)
+	c.Reject(err)
+
+	return c
+}
+
+// Never is resolved only when ctx is done
+func Never[T any](ctx context.Context) async.Task[T] {
+	return NewControlled[T](ctx)
+}
+
+// After is resolved with data once d passes on clock, the system clock by default
+func After[T any](ctx context.Context, d time.Duration, data T, clock ...async.Clock) async.Task[T] {
+	c := NewControlled[T](ctx)
+	timerClock := async.SystemClock()
+	if len(clock) > 0 && clock[0] != nil {
+		timerClock = clock[0]
+	}
+	timer := timerClock.NewTimer(d)
+
+	go func() {
+		defer timer.Stop()
+
+		select {
+		case <-timer.C():
+			c.Resolve(data)
+		case <-c.done:
+		}
+	}()
+
+	return c
+}
+
+// Resolve reports whether the task was resolved by this call
+func (c *Controlled[T]) Resolve(data T) bool {
+	return c.settle(data, nil)
+}
+
+// Reject reports whether the task was rejected by this call
+func (c *Controlled[T]) Reject(err error) bool {
+	var zero T
+
+	return c.settle(zero, err)
+}
+
+// Settled reports whether the task is resolved or rejected
+func (c *Controlled[T]) Settled() bool {
+	select {
+	case <-c.done:
+		return true
+	case <-c.ctx.Done():
+		var zero T
+		c.settle(zero, async.ErrTaskContextCancelled)
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *Controlled[T]) settle(data T, err error) bool {
+	settled := false
+	c.once.Do(func() {
+		c.data, c.err = data, err
+		settled = true
+		close(c.done)
+		if err != nil {
+			c.cancel(err)
+		}
+	})
+
+	return settled
+}
+
+func (c *Controlled[T]) Await() (T, error) {
+	select {
+	case <-c.done:
+	case <-c.ctx.Done():
+		var zero T
+		c.settle(zero, async.ErrTaskContextCancelled)
+	}
+
+	return c.data, c.err
+}
+
+func (c *Controlled[T]) Subscribe(cb func(data T, err error)) {
+	go cb(c.Await())
+}
+
+func (c *Controlled[T]) GetContext() context.Context {
+	return c.ctx
+}
+
+func (c *Controlled[T]) GetError() error {
+	_, err := c.Await()
+	return err
+}
@@ -0,0 +1,130 @@
+package asynctest
+
+import (
+	"context"
+	"errors"
+	"github.com/aybjax/aysync/async"
+	"github.com/stretchr/testify/require"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestControlled(t *testing.T) {
+	err := errors.New("i am error")
+
+	t.Run("resolve", func(t *testing.T) {
+		task := NewControlled[int](nil)
+		AssertPending(t, task)
+
+		require.True(t, task.Resolve(1))
+		require.False(t, task.Reject(err))
+		AssertResolvesTo(t, task, 1)
+	})
+
+	t.Run("reject cancels context", func(t *testing.T) {
+		task := NewControlled[int](nil)
+		require.True(t, task.Reject(err))
+
+		AssertRejectsWith(t, task, err)
+		require.Equal(t, err, context.Cause(task.GetContext()))
+	})
+
+	t.Run("never is cancelled with context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.TODO())
+		task := Never[int](ctx)
+		AssertPending(t, task)
+
+		cancel()
+		AssertRejectsWith(t, task, async.ErrTaskContextCancelled)
+	})
+
+	t.Run("after", func(t *testing.T) {
+		clock := NewFakeClock(time.Now())
+		task := After(nil, time.Minute, "done", clock)
+		AssertPending(t, task)
+
+		clock.Advance(time.Minute)
+		AssertResolvesTo(t, task, "done")
+	})
+
+	t.Run("composes with FMap", func(t *testing.T) {
+		resolved := async.FMap(nil, Resolved(nil, 1), func(i int) (string, error) {
+			return strconv.Itoa(i), nil
+		})
+		rejected := async.FMap(nil, Rejected[int](nil, err), func(i int) (string, error) {
+			return strconv.Itoa(i), nil
+		})
+
+		AssertResolvesTo(t, resolved, "1")
+		AssertRejectsWith(t, rejected, err)
+	})
+}
+
+func TestAssertions(t *testing.T) {
+	err := errors.New("i am error")
+	prevTimeout := AwaitTimeout
+	AwaitTimeout = 20 * time.Millisecond
+	defer func() {
+		AwaitTimeout = prevTimeout
+	}()
+
+	testCases := []struct {
+		name   string
+		assert func(tb *recordingTB) bool
+	}{
+		{
+			name: "resolves to another value",
+			assert: func(tb *recordingTB) bool {
+				return AssertResolvesTo(tb, Resolved(nil, 1), 2)
+			},
+		},
+		{
+			name: "resolves to is rejected",
+			assert: func(tb *recordingTB) bool {
+				return AssertResolvesTo(tb, Rejected[int](nil, err), 0)
+			},
+		},
+		{
+			name: "resolves to times out",
+			assert: func(tb *recordingTB) bool {
+				return AssertResolvesTo(tb, Never[int](nil), 0)
+			},
+		},
+		{
+			name: "rejects with is resolved",
+			assert: func(tb *recordingTB) bool {
+				return AssertRejectsWith(tb, Resolved(nil, 1), err)
+			},
+		},
+		{
+			name: "rejects with another error",
+			assert: func(tb *recordingTB) bool {
+				return AssertRejectsWith(tb, Rejected[int](nil, errors.New("other")), err)
+			},
+		},
+		{
+			name: "pending is settled",
+			assert: func(tb *recordingTB) bool {
+				return AssertPending(tb, Resolved(nil, 1))
+			},
+		},
+		{
+			name: "pending task of async package is settled",
+			assert: func(tb *recordingTB) bool {
+				return AssertPending(tb, async.NewTask(nil, func() (int, error) {
+					return 1, nil
+				}))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := &recordingTB{TB: t}
+
+			require.False(t, tc.assert(tb))
+			require.Len(t, tb.errors, 1)
+		})
+	}
+}